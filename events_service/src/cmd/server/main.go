@@ -2,15 +2,23 @@ package main
 
 import (
 	client "eventservice/src/internal/adaptors/auth_grpc_client"
+	"eventservice/src/internal/adaptors/cache"
+	"eventservice/src/internal/adaptors/payments"
 	"eventservice/src/internal/adaptors/persistance"
 	"eventservice/src/internal/config"
+	"eventservice/src/internal/core"
 	"eventservice/src/internal/interfaces/input/api/routes"
 	"eventservice/src/internal/interfaces/input/rest/handler/event"
+	"eventservice/src/internal/interfaces/input/rest/handler/venue"
 	eventservice "eventservice/src/internal/usecase/event"
+	venueservice "eventservice/src/internal/usecase/venue"
 	"eventservice/src/pkg/migrate"
+	"eventservice/src/pkg/ticket"
 	"fmt"
 	"log"
 	"net/http"
+	"time"
+	_ "time/tzdata" // Event time zones must resolve even without a system zone database
 
 	"github.com/go-redis/redis/v8"
 )
@@ -31,7 +39,7 @@ func main() {
 	defer database.Close()
 	fmt.Println("Connected to database")
 
-	// Connect to Redis (optional for this service, but keeping for consistency)
+	// Connect to Redis (used to cache analytics reports)
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     "localhost:6379", // Redis default address
 		Password: "",               // No password by default
@@ -58,15 +66,82 @@ func main() {
 
 	// Initialize repositories
 	eventRepo := persistance.NewEventRepo(database)
+	venueRepo := persistance.NewVenueRepo(database)
+
+	// Ticket codes are signed so they cannot be forged at the door. Without a
+	// usable secret bookings still work, but no tickets are issued.
+	ticketSigner, err := ticket.NewSigner(config.TICKET_SECRET)
+	if err != nil {
+		log.Printf("WARNING: tickets are disabled, set TICKET_SECRET to enable them: %v", err)
+	}
+
+	seatHoldTTL, err := eventservice.ParseSeatHoldTTL(config.SEAT_HOLD_TTL)
+	if err != nil {
+		log.Fatalf("Failed to load seat hold TTL: %v", err)
+	}
+
+	paymentProvider, err := payments.NewProvider(config.PAYMENT_PROVIDER)
+	if err != nil {
+		log.Fatalf("Failed to set up payments: %v", err)
+	}
 
 	// Initialize services
-	eventService := eventservice.NewService(&eventRepo)
+	analyticsCache := cache.NewRedisCache(redisClient)
+	eventService := eventservice.NewService(&eventRepo, ticketSigner, &analyticsCache, seatHoldTTL, paymentProvider)
+	venueService := venueservice.NewService(&venueRepo)
+
+	// Periodically mark events that have ended as completed
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			completed, err := eventService.CompleteFinishedEvents()
+			if err != nil {
+				log.Printf("Failed to complete finished events: %v", err)
+			} else if completed > 0 {
+				log.Printf("Marked %d events as completed", completed)
+			}
+			<-ticker.C
+		}
+	}()
+
+	// Release lapsed seat holds; their expiry is stored, so holds that lapsed
+	// while the service was down are released on the first pass
+	go func() {
+		ticker := time.NewTicker(core.SeatHoldSweepInterval)
+		defer ticker.Stop()
+		for {
+			released, err := eventService.ReleaseExpiredSeatHolds()
+			if err != nil {
+				log.Printf("Failed to release expired seat holds: %v", err)
+			} else if released > 0 {
+				log.Printf("Released %d expired seat holds", released)
+			}
+			<-ticker.C
+		}
+	}()
+
+	// Retry refunds the payment provider could not take yet
+	go func() {
+		ticker := time.NewTicker(core.RefundRetryInterval)
+		defer ticker.Stop()
+		for {
+			refunded, err := eventService.ProcessPendingRefunds()
+			if err != nil {
+				log.Printf("Failed to process pending refunds: %v", err)
+			} else if refunded > 0 {
+				log.Printf("Processed %d refunds", refunded)
+			}
+			<-ticker.C
+		}
+	}()
 
 	// Initialize handlers
 	eventHandler := event.NewEventHandler(eventService)
+	venueHandler := venue.NewVenueHandler(venueService)
 
 	// Initialize routes with gRPC client
-	router := routes.InitRoutes(eventHandler, grpcClient)
+	router := routes.InitRoutes(eventHandler, venueHandler, grpcClient)
 
 	// Start server
 	port := config.APP_PORT
@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisCache keeps cached results in Redis
+type RedisCache struct {
+	client *redis.Client
+}
+
+func NewRedisCache(client *redis.Client) RedisCache {
+	return RedisCache{client: client}
+}
+
+// Get returns the value stored under key; a missing key is reported as not found
+func (rc *RedisCache) Get(key string) ([]byte, bool, error) {
+	value, err := rc.client.Get(context.Background(), key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set stores value under key until ttl expires
+func (rc *RedisCache) Set(key string, value []byte, ttl time.Duration) error {
+	return rc.client.Set(context.Background(), key, value, ttl).Err()
+}
@@ -0,0 +1,119 @@
+package payments
+
+import (
+	"eventservice/src/internal/core"
+	"fmt"
+	"sync"
+)
+
+// Payment methods the fake provider treats specially; any other method pays
+const (
+	FakeMethodDeclined      = "pm_card_declined"  // The charge is declined
+	FakeMethodProviderError = "pm_provider_error" // The provider cannot be reached
+	FakeMethodRefundFails   = "pm_refund_fails"   // Charges succeed but refunds error
+)
+
+// FakeProvider is an in-memory payment provider for development and tests. It
+// keeps payments for the life of the process only.
+type FakeProvider struct {
+	mu       sync.Mutex
+	next     int
+	intents  map[string]*core.PaymentIntent // By idempotency key
+	payments map[string]*fakePayment        // By payment ID
+	refunds  map[string]*core.PaymentRefund // By idempotency key
+}
+
+type fakePayment struct {
+	method   string
+	amount   int64
+	refunded int64
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{
+		intents:  make(map[string]*core.PaymentIntent),
+		payments: make(map[string]*fakePayment),
+		refunds:  make(map[string]*core.PaymentRefund),
+	}
+}
+
+// NewProvider returns the payment provider configured by name; empty means fake
+func NewProvider(name string) (core.PaymentProvider, error) {
+	switch name {
+	case "", "fake":
+		return NewFakeProvider(), nil
+	default:
+		return nil, fmt.Errorf("unsupported payment provider '%s'", name)
+	}
+}
+
+// CreatePaymentIntent charges immediately unless the method is one of the
+// failing FakeMethod values
+func (fp *FakeProvider) CreatePaymentIntent(request *core.PaymentIntentRequest) (*core.PaymentIntent, error) {
+	if request.PaymentMethod == FakeMethodProviderError {
+		return nil, fmt.Errorf("payment provider unavailable")
+	}
+	if request.Amount <= 0 {
+		return nil, fmt.Errorf("amount must be greater than 0")
+	}
+
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	if intent, ok := fp.intents[request.IdempotencyKey]; ok && request.IdempotencyKey != "" {
+		copied := *intent
+		return &copied, nil
+	}
+
+	fp.next++
+	intent := &core.PaymentIntent{
+		ID:       fmt.Sprintf("pi_fake_%d", fp.next),
+		Amount:   request.Amount,
+		Currency: request.Currency,
+		Status:   core.PaymentIntentSucceeded,
+	}
+	if request.PaymentMethod == FakeMethodDeclined {
+		intent.Status = core.PaymentIntentFailed
+		intent.FailureReason = "card declined"
+	} else {
+		fp.payments[intent.ID] = &fakePayment{method: request.PaymentMethod, amount: request.Amount}
+	}
+	if request.IdempotencyKey != "" {
+		fp.intents[request.IdempotencyKey] = intent
+	}
+
+	copied := *intent
+	return &copied, nil
+}
+
+// Refund returns part or all of a fake payment
+func (fp *FakeProvider) Refund(request *core.RefundRequest) (*core.PaymentRefund, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	if refund, ok := fp.refunds[request.IdempotencyKey]; ok && request.IdempotencyKey != "" {
+		copied := *refund
+		return &copied, nil
+	}
+
+	payment, ok := fp.payments[request.PaymentID]
+	if !ok {
+		return nil, fmt.Errorf("payment %s not found", request.PaymentID)
+	}
+	if payment.method == FakeMethodRefundFails {
+		return nil, fmt.Errorf("refunds are failing for payment %s", request.PaymentID)
+	}
+	if request.Amount <= 0 || payment.refunded+request.Amount > payment.amount {
+		return nil, fmt.Errorf("cannot refund %d of payment %s, %d left", request.Amount, request.PaymentID, payment.amount-payment.refunded)
+	}
+
+	fp.next++
+	payment.refunded += request.Amount
+	refund := &core.PaymentRefund{ID: fmt.Sprintf("re_fake_%d", fp.next), Amount: request.Amount}
+	if request.IdempotencyKey != "" {
+		fp.refunds[request.IdempotencyKey] = refund
+	}
+
+	copied := *refund
+	return &copied, nil
+}
@@ -0,0 +1,139 @@
+package persistance
+
+import (
+	"database/sql"
+	"eventservice/src/internal/core"
+	"fmt"
+	"time"
+)
+
+// analyticsScope selects the events an analytics report covers: those whose
+// team organizer $1 is on, optionally only event $2 and starting in [$3, $4)
+const analyticsScope = `
+	WITH scoped AS (
+		SELECT e.event_id, e.event_name, e.status, e.starts_at, e.ends_at, e.capacity, e.filled
+		FROM events_schema.events e
+		JOIN events_schema.event_team tm ON tm.event_id = e.event_id AND tm.organizer_id = $1
+		WHERE ($2 = 0 OR e.event_id = $2)
+		  AND ($3::TIMESTAMPTZ IS NULL OR e.starts_at >= $3)
+		  AND ($4::TIMESTAMPTZ IS NULL OR e.starts_at < $4)
+	)`
+
+// GetOrganizerAnalytics computes the booking figures of each event in scope
+// and the bookings per day and hour in the filters' time zone. Totals and
+// rates are left to the caller.
+func (er *EventRepo) GetOrganizerAnalytics(organizerID int, filters *core.AnalyticsFilters) (*core.Analytics, error) {
+	analytics := &core.Analytics{From: filters.From, To: filters.To, TimeZone: filters.TimeZone}
+	args := []interface{}{organizerID, filters.EventID, nullableTime(filters.From), nullableTime(filters.To)}
+
+	eventsQuery := analyticsScope + `
+		SELECT s.event_id, s.event_name, s.status, s.starts_at, s.ends_at < NOW(), s.capacity, s.filled,
+			COALESCE(b.bookings, 0), COALESCE(b.seats, 0), COALESCE(b.confirmed, 0), COALESCE(b.checked_in, 0),
+			COALESCE(c.cancellations, 0), COALESCE(c.seats, 0)
+		FROM scoped s
+		LEFT JOIN (
+			SELECT ub.event_id, COUNT(*) AS bookings, SUM(ub.seats) AS seats,
+				COUNT(*) FILTER (WHERE ub.status = 'confirmed') AS confirmed,
+				COUNT(*) FILTER (WHERE ub.status = 'confirmed' AND ub.checked_in_at IS NOT NULL) AS checked_in
+			FROM events_schema.userbooked_events ub
+			WHERE ub.event_id IN (SELECT event_id FROM scoped)
+			GROUP BY ub.event_id
+		) b ON b.event_id = s.event_id
+		LEFT JOIN (
+			SELECT bc.event_id, COUNT(*) AS cancellations, SUM(bc.seats) AS seats
+			FROM events_schema.booking_cancellations bc
+			WHERE bc.event_id IN (SELECT event_id FROM scoped)
+			GROUP BY bc.event_id
+		) c ON c.event_id = s.event_id
+		ORDER BY s.starts_at, s.event_id`
+
+	rows, err := er.db.db.Query(eventsQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event analytics: %v", err)
+	}
+	defer rows.Close()
+
+	analytics.Events = []core.EventAnalytics{}
+	for rows.Next() {
+		var event core.EventAnalytics
+		var confirmed, checkedIn int
+		err := rows.Scan(&event.EventID, &event.EventName, &event.Status, &event.StartsAt, &event.Ended,
+			&event.Capacity, &event.Filled, &event.Bookings, &event.BookedSeats, &confirmed, &checkedIn,
+			&event.Cancellations, &event.CancelledSeats)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event analytics: %v", err)
+		}
+		// Attendance is only known once the doors have closed
+		if event.Ended {
+			event.CheckedIn = checkedIn
+			event.NoShows = confirmed - checkedIn
+		}
+		analytics.Events = append(analytics.Events, event)
+	}
+	rows.Close()
+
+	dailyQuery := analyticsScope + `
+		SELECT day::TEXT, SUM(bookings), SUM(seats), SUM(cancellations), SUM(cancelled_seats)
+		FROM (
+			SELECT (ub.booked_at AT TIME ZONE $5)::DATE AS day, 1 AS bookings, ub.seats AS seats,
+				0 AS cancellations, 0 AS cancelled_seats
+			FROM events_schema.userbooked_events ub
+			WHERE ub.event_id IN (SELECT event_id FROM scoped)
+			UNION ALL
+			SELECT (bc.cancelled_at AT TIME ZONE $5)::DATE, 0, 0, 1, bc.seats
+			FROM events_schema.booking_cancellations bc
+			WHERE bc.event_id IN (SELECT event_id FROM scoped)
+		) activity
+		GROUP BY day
+		ORDER BY day`
+
+	rows, err = er.db.db.Query(dailyQuery, append(args, filters.TimeZone)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily bookings: %v", err)
+	}
+	defer rows.Close()
+
+	analytics.Daily = []core.DailyBookings{}
+	for rows.Next() {
+		var day core.DailyBookings
+		err := rows.Scan(&day.Date, &day.Bookings, &day.BookedSeats, &day.Cancellations, &day.CancelledSeats)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan daily bookings: %v", err)
+		}
+		analytics.Daily = append(analytics.Daily, day)
+	}
+	rows.Close()
+
+	hourlyQuery := analyticsScope + `
+		SELECT hours.hour, COUNT(ub.booking_id)
+		FROM generate_series(0, 23) AS hours(hour)
+		LEFT JOIN events_schema.userbooked_events ub
+			ON ub.event_id IN (SELECT event_id FROM scoped)
+			AND EXTRACT(HOUR FROM ub.booked_at AT TIME ZONE $5)::INT = hours.hour
+		GROUP BY hours.hour
+		ORDER BY hours.hour`
+
+	rows, err = er.db.db.Query(hourlyQuery, append(args, filters.TimeZone)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hourly bookings: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hour core.HourlyBookings
+		if err := rows.Scan(&hour.Hour, &hour.Bookings); err != nil {
+			return nil, fmt.Errorf("failed to scan hourly bookings: %v", err)
+		}
+		analytics.Hourly = append(analytics.Hourly, hour)
+	}
+
+	return analytics, nil
+}
+
+// nullableTime maps an unset time to NULL
+func nullableTime(t *time.Time) interface{} {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return *t
+}
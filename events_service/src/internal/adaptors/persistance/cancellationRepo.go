@@ -0,0 +1,64 @@
+package persistance
+
+import (
+	"database/sql"
+	"eventservice/src/internal/core"
+	"fmt"
+)
+
+// lateCancellationColumns is the column list read by scanLateCancellations;
+// queries alias cancellations as c, events as e and users as u
+const lateCancellationColumns = `c.cancellation_id, c.event_id, e.event_name, c.cid, COALESCE(u.username, ''), c.seats,
+	c.penalty, COALESCE(c.currency, e.currency), c.cancelled_at`
+
+// scanLateCancellations reads the lateCancellationColumns of every row
+func scanLateCancellations(rows *sql.Rows) ([]core.LateCancellation, error) {
+	defer rows.Close()
+
+	var cancellations []core.LateCancellation
+	for rows.Next() {
+		var cancellation core.LateCancellation
+		err := rows.Scan(&cancellation.CancellationID, &cancellation.EventID, &cancellation.EventName, &cancellation.CID,
+			&cancellation.CUsername, &cancellation.Seats, &cancellation.Penalty, &cancellation.Currency, &cancellation.CancelledAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan late cancellation: %v", err)
+		}
+		cancellations = append(cancellations, cancellation)
+	}
+
+	return cancellations, nil
+}
+
+// GetUserLateCancellations lists the late cancellations recorded against a
+// customer, newest first
+func (er *EventRepo) GetUserLateCancellations(customerID int) ([]core.LateCancellation, error) {
+	query := `
+		SELECT ` + lateCancellationColumns + `
+		FROM events_schema.booking_cancellations c
+		JOIN events_schema.events e ON e.event_id = c.event_id
+		LEFT JOIN users u ON u.cid = c.cid
+		WHERE c.cid = $1 AND c.late
+		ORDER BY c.cancelled_at DESC, c.cancellation_id DESC`
+	rows, err := er.db.db.Query(query, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get late cancellations: %v", err)
+	}
+	return scanLateCancellations(rows)
+}
+
+// GetEventLateCancellations lists the late cancellations of an event for an
+// organizer on its team, newest first
+func (er *EventRepo) GetEventLateCancellations(eventID int, organizerID int) ([]core.LateCancellation, error) {
+	query := `
+		SELECT ` + lateCancellationColumns + `
+		FROM events_schema.booking_cancellations c
+		JOIN events_schema.events e ON e.event_id = c.event_id
+		LEFT JOIN users u ON u.cid = c.cid
+		WHERE c.event_id = $1 AND c.late AND ` + teamAccess + `
+		ORDER BY c.cancelled_at DESC, c.cancellation_id DESC`
+	rows, err := er.db.db.Query(query, eventID, organizerID, rolesWith(core.PermissionView))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get late cancellations: %v", err)
+	}
+	return scanLateCancellations(rows)
+}
@@ -2,12 +2,47 @@ package persistance
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"eventservice/src/internal/core"
 	"fmt"
 	"strings"
 	"time"
+	"unicode"
+
+	"github.com/lib/pq"
 )
 
+// customerBookingLock namespaces the per-customer advisory lock taken while booking
+const customerBookingLock = 1
+
+// maxSearchTerms caps the words of a search query that are matched
+const maxSearchTerms = 10
+
+// eventSortColumns maps listing sort keys to their SQL expression and the type
+// a cursor value is cast back to. relevance needs the search query joined in;
+// distance depends on the near point and is built per query.
+var eventSortColumns = map[string]struct {
+	expr string
+	cast string
+}{
+	core.EventSortDate:      {"e.starts_at", "TIMESTAMPTZ"},
+	core.EventSortName:      {"e.event_name", "TEXT"},
+	core.EventSortCapacity:  {"e.capacity", "INTEGER"},
+	core.EventSortSeatsLeft: {"(e.capacity - e.filled - e.held)", "INTEGER"},
+	core.EventSortRelevance: {"ts_rank(e.search_vector, query)::FLOAT8", "FLOAT8"},
+}
+
+// eventColumns is the column list read by scanEvent; queries alias events as e
+const eventColumns = `e.event_id, e.event_name, e.description, e.organizer_id, e.venue_id, e.place, e.starts_at, e.ends_at,
+	e.time_zone, e.capacity, e.filled, e.held, e.max_seats_per_booking, e.price, e.currency, e.cancellation_cutoff_minutes, e.cancellation_mode, e.late_cancellation_penalty,
+	e.status, e.series_id, e.sequence, e.created_at, e.updated_at`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 type EventRepo struct {
 	db *Database
 }
@@ -18,73 +53,219 @@ func NewEventRepo(d *Database) EventRepo {
 
 // CreateEvent creates a new event (organizer functionality)
 func (er *EventRepo) CreateEvent(event *core.Event) (*core.Event, error) {
-	// Check place availability first
-	var placeAvailable bool
-	checkQuery := `SELECT events_schema.check_place_availability($1, $2, $3, $4)`
-	err := er.db.db.QueryRow(checkQuery, event.Place, event.EventDate, event.StartTime, event.EndTime).Scan(&placeAvailable)
+	tx, err := er.db.db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to check place availability: %v", err)
+		return nil, fmt.Errorf("failed to start transaction: %v", err)
 	}
+	defer tx.Rollback()
 
-	if !placeAvailable {
-		return nil, fmt.Errorf("place '%s' is not available for the given time slot", event.Place)
+	if err := assignVenue(tx, event); err != nil {
+		return nil, err
 	}
 
-	// Create the event
-	query := `
-		INSERT INTO events_schema.events (event_name, organizer_id, place, event_date, start_time, end_time, capacity)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
-		RETURNING event_id, event_name, organizer_id, place, event_date, start_time, end_time, capacity, filled, created_at, updated_at`
+	// The venue overlap constraint decides availability
+	eventID, err := insertEvent(tx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to create event: %v", err)
+	}
 
-	var createdEvent core.Event
-	var startTime, endTime time.Time // Scan TIME fields as time.Time
-	err = er.db.db.QueryRow(query, event.EventName, event.OrganizerID,
-		event.Place, event.EventDate, event.StartTime, event.EndTime, event.Capacity).
-		Scan(&createdEvent.EventID, &createdEvent.EventName, &createdEvent.OrganizerID,
-			&createdEvent.Place, &createdEvent.EventDate, &startTime,
-			&endTime, &createdEvent.Capacity, &createdEvent.Filled,
-			&createdEvent.CreatedAt, &createdEvent.UpdatedAt)
+	// Re-read so the tiers come back with their IDs
+	return er.GetEventByID(eventID)
+}
 
+// assignVenue resolves the venue of a new event from its venue ID or place
+// name and checks the event fits in it
+func assignVenue(tx *sql.Tx, event *core.Event) error {
+	venue, err := resolveVenue(tx, event.OrganizerID, event.VenueID, event.Place)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create event: %v", err)
+		return err
+	}
+	if event.Capacity > venue.MaxCapacity {
+		return fmt.Errorf("capacity (%d) exceeds the maximum capacity of venue '%s' (%d)",
+			event.Capacity, venue.Name, venue.MaxCapacity)
 	}
 
-	// Format the date and times for JSON response
-	createdEvent.EventDateStr = createdEvent.EventDate.Format("2006-01-02")
-	createdEvent.StartTime = startTime.Format("15:04")
-	createdEvent.EndTime = endTime.Format("15:04")
-	// Calculate seats left
-	createdEvent.SeatsLeft = createdEvent.Capacity - createdEvent.Filled
+	event.VenueID = venue.VenueID
+	event.Place = venue.Name
+	return nil
+}
 
-	return &createdEvent, nil
+// insertEvent creates the event row and its ticket tiers, returning the new
+// event ID. An overlap at the venue is returned as a VenueUnavailableError.
+func insertEvent(tx *sql.Tx, event *core.Event) (int, error) {
+	query := `
+		INSERT INTO events_schema.events (event_name, description, organizer_id, venue_id, place, starts_at, ends_at, time_zone, capacity, max_seats_per_booking, price, currency,
+			cancellation_cutoff_minutes, cancellation_mode, late_cancellation_penalty, status, series_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		RETURNING event_id`
+
+	var eventID int
+	err := guardVenueOverlap(tx, event.VenueID, event.StartsAt, event.EndsAt, 0, func() error {
+		return tx.QueryRow(query, event.EventName, event.Description, event.OrganizerID, event.VenueID, event.Place, event.StartsAt,
+			event.EndsAt, event.TimeZone, event.Capacity, event.MaxSeatsPerBooking, event.Price, event.Currency,
+			event.CancellationPolicy.CutoffMinutes, event.CancellationPolicy.Mode, event.CancellationPolicy.LatePenalty, event.Status,
+			nullableID(event.SeriesID)).Scan(&eventID)
+	})
+	if err != nil {
+		var venueErr *core.VenueUnavailableError
+		if errors.As(err, &venueErr) {
+			return 0, err
+		}
+		return 0, fmt.Errorf("failed to create event: %v", err)
+	}
+
+	if err := insertTiers(tx, eventID, event.Tiers); err != nil {
+		return 0, err
+	}
+
+	if err := addEventOwner(tx, eventID, event.OrganizerID); err != nil {
+		return 0, err
+	}
+
+	return eventID, nil
 }
 
-// GetAllEventsForCustomers returns all events with organizer name (public endpoint)
-func (er *EventRepo) GetAllEventsForCustomers(filters *core.EventFilters) ([]core.EventResponse, error) {
+// GetAllEventsForCustomers returns one page of events with organizer name (public
+// endpoint). Pages are keyed on the sort value and event ID of the last event,
+// so rows created meanwhile neither repeat nor shift later pages. With a search
+// query, events carry a snippet of the matches.
+func (er *EventRepo) GetAllEventsForCustomers(filters *core.EventFilters) (*core.EventPage, error) {
+	var args []interface{}
+	argIndex := 1
+	var conditions []string
+
+	snippet := `''`
+	search := ""
+	if tsQuery := prefixTSQuery(filters.Query); tsQuery != "" {
+		snippet = `ts_headline('english', e.event_name || ' - ' || e.place || '. ' || e.description, query,
+			'StartSel=<mark>, StopSel=</mark>, MinWords=8, MaxWords=25, MaxFragments=2, FragmentDelimiter=" ... "')`
+		search = fmt.Sprintf("CROSS JOIN to_tsquery('english', $%d) AS query", argIndex)
+		conditions = append(conditions, "e.search_vector @@ query")
+		args = append(args, tsQuery)
+		argIndex++
+	}
+
+	// Near searches only see venues with a position, narrowed by the bounding
+	// box before the exact distance is computed
+	distance := `NULL::FLOAT8`
+	if filters.Near != nil {
+		distance = distanceSQL(argIndex, argIndex+1)
+		args = append(args, filters.Near.Latitude, filters.Near.Longitude)
+		argIndex += 2
+		conditions = append(conditions, "v.latitude IS NOT NULL")
+
+		if filters.RadiusKm > 0 {
+			box := filters.Near.BoundingBox(filters.RadiusKm)
+			conditions = append(conditions, fmt.Sprintf("v.latitude BETWEEN $%d AND $%d", argIndex, argIndex+1))
+			args = append(args, box.MinLatitude, box.MaxLatitude)
+			argIndex += 2
+			if !box.WrapsLongitude {
+				conditions = append(conditions, fmt.Sprintf("v.longitude BETWEEN $%d AND $%d", argIndex, argIndex+1))
+				args = append(args, box.MinLongitude, box.MaxLongitude)
+				argIndex += 2
+			}
+
+			conditions = append(conditions, fmt.Sprintf("%s <= $%d", distance, argIndex))
+			args = append(args, filters.RadiusKm)
+			argIndex++
+		}
+	}
+
+	sortColumn, ok := eventSortColumns[filters.Sort]
+	if filters.Sort == core.EventSortDistance && filters.Near != nil {
+		sortColumn.expr, sortColumn.cast, ok = distance, "FLOAT8", true
+	}
+	if !ok || (filters.Sort == core.EventSortRelevance && search == "") {
+		sortColumn = eventSortColumns[core.EventSortDate]
+	}
+
 	query := `
 		SELECT 
-			e.event_id, e.event_name, e.organizer_id, e.place, 
-			e.event_date, e.start_time, e.end_time, e.capacity, 
-			e.filled, e.created_at, e.updated_at,
-			u.username as organizer_name
+			e.event_id, e.event_name, e.description, e.organizer_id, e.venue_id, e.place, 
+			e.starts_at, e.ends_at, e.time_zone, e.capacity,
+			e.filled, e.held, e.max_seats_per_booking, e.price, e.currency,
+			e.cancellation_cutoff_minutes, e.cancellation_mode, e.late_cancellation_penalty, e.status, e.created_at, e.updated_at,
+			u.username as organizer_name, ` + snippet + `, ` + distance + `, (` + sortColumn.expr + `)::TEXT
 		FROM events_schema.events e
 		JOIN users u ON e.organizer_id = u.cid
-	`
+		JOIN events_schema.venues v ON v.venue_id = e.venue_id
+		` + search
 
-	var args []interface{}
-	argIndex := 1
-	var conditions []string
+	// Drafts are never public
+	if filters.Status != "" {
+		conditions = append(conditions, fmt.Sprintf("e.status = $%d", argIndex))
+		args = append(args, filters.Status)
+		argIndex++
+	} else {
+		conditions = append(conditions, fmt.Sprintf("e.status <> '%s'", core.EventStatusDraft))
+	}
+
+	// Days are taken in the requested zone, or else in each event's own zone
+	zone := "e.time_zone"
+	if filters.TimeZone != "" && (filters.Date != "" || filters.From != "" || filters.To != "") {
+		zone = fmt.Sprintf("$%d", argIndex)
+		args = append(args, filters.TimeZone)
+		argIndex++
+	}
 
-	// Apply filters
+	// Apply filters; multi-day events match every day they run on
 	if filters.Date != "" {
-		conditions = append(conditions, fmt.Sprintf("e.event_date = $%d", argIndex))
+		conditions = append(conditions, fmt.Sprintf(
+			"e.starts_at < (($%[1]d::DATE + 1)::TIMESTAMP AT TIME ZONE %[2]s) AND e.ends_at > ($%[1]d::DATE::TIMESTAMP AT TIME ZONE %[2]s)",
+			argIndex, zone))
 		args = append(args, filters.Date)
 		argIndex++
 	}
 
+	if filters.From != "" {
+		conditions = append(conditions, fmt.Sprintf("e.ends_at > ($%d::DATE::TIMESTAMP AT TIME ZONE %s)", argIndex, zone))
+		args = append(args, filters.From)
+		argIndex++
+	}
+
+	if filters.To != "" {
+		conditions = append(conditions, fmt.Sprintf("e.starts_at < (($%d::DATE + 1)::TIMESTAMP AT TIME ZONE %s)", argIndex, zone))
+		args = append(args, filters.To)
+		argIndex++
+	}
+
+	if filters.Upcoming {
+		conditions = append(conditions, "e.ends_at > NOW()")
+	}
+	if filters.Past {
+		conditions = append(conditions, "e.ends_at <= NOW()")
+	}
+
+	if filters.HasSeats {
+		conditions = append(conditions, "e.filled + e.held < e.capacity")
+	}
+
+	if filters.MinCapacity > 0 {
+		conditions = append(conditions, fmt.Sprintf("e.capacity >= $%d", argIndex))
+		args = append(args, filters.MinCapacity)
+		argIndex++
+	}
+
+	if filters.MaxCapacity > 0 {
+		conditions = append(conditions, fmt.Sprintf("e.capacity <= $%d", argIndex))
+		args = append(args, filters.MaxCapacity)
+		argIndex++
+	}
+
+	if filters.VenueID != 0 {
+		conditions = append(conditions, fmt.Sprintf("e.venue_id = $%d", argIndex))
+		args = append(args, filters.VenueID)
+		argIndex++
+	}
+
+	// Place matches venue names regardless of case and spacing
 	if filters.Place != "" {
-		conditions = append(conditions, fmt.Sprintf("LOWER(e.place) LIKE LOWER($%d)", argIndex))
-		args = append(args, "%"+filters.Place+"%")
+		conditions = append(conditions, fmt.Sprintf("v.name_key LIKE $%d", argIndex))
+		args = append(args, "%"+core.NormalizeVenueName(filters.Place)+"%")
 		argIndex++
 	}
 
@@ -94,12 +275,30 @@ func (er *EventRepo) GetAllEventsForCustomers(filters *core.EventFilters) ([]cor
 		argIndex++
 	}
 
+	// Continue after the last event of the previous page
+	direction, comparison := "ASC", ">"
+	if filters.Order == core.SortDesc {
+		direction, comparison = "DESC", "<"
+	}
+	if filters.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, e.event_id) %s ($%d::%s, $%d)",
+			sortColumn.expr, comparison, argIndex, sortColumn.cast, argIndex+1))
+		args = append(args, filters.After.Value, filters.After.ID)
+		argIndex += 2
+	}
+
 	// Add WHERE clause if we have conditions
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	query += " ORDER BY e.event_date ASC, e.start_time ASC"
+	// One extra row tells whether another page follows
+	limit := filters.Limit
+	if limit <= 0 {
+		limit = core.DefaultPageSize
+	}
+	query += fmt.Sprintf(" ORDER BY %s %s, e.event_id %s LIMIT $%d", sortColumn.expr, direction, direction, argIndex)
+	args = append(args, limit+1)
 
 	rows, err := er.db.db.Query(query, args...)
 	if err != nil {
@@ -107,43 +306,85 @@ func (er *EventRepo) GetAllEventsForCustomers(filters *core.EventFilters) ([]cor
 	}
 	defer rows.Close()
 
-	var events []core.EventResponse
+	page := &core.EventPage{Events: []core.EventResponse{}}
+	var lastSortValue string
 	for rows.Next() {
 		var event core.EventResponse
-		var filled int
+		var filled, held int
 		var createdAt, updatedAt time.Time
-		var eventDate time.Time          // Scan as time.Time first, then format
-		var startTime, endTime time.Time // Scan as time.Time first, then format
+		var sortValue string
 
 		err := rows.Scan(
-			&event.EventID, &event.EventName, &event.OrganizerID, &event.Place,
-			&eventDate, &startTime, &endTime, &event.Capacity,
-			&filled, &createdAt, &updatedAt, &event.OrganizerName,
+			&event.EventID, &event.EventName, &event.Description, &event.OrganizerID, &event.VenueID, &event.Place,
+			&event.StartsAt, &event.EndsAt, &event.TimeZone, &event.Capacity,
+			&filled, &held, &event.MaxSeatsPerBooking, &event.Price, &event.Currency,
+			&event.CancellationPolicy.CutoffMinutes, &event.CancellationPolicy.Mode, &event.CancellationPolicy.LatePenalty,
+			&event.Status, &createdAt, &updatedAt, &event.OrganizerName, &event.Snippet, &event.DistanceKm, &sortValue,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %v", err)
 		}
 
-		// Format the date and times
-		event.EventDate = eventDate.Format("2006-01-02")
-		event.StartTime = startTime.Format("15:04")
-		event.EndTime = endTime.Format("15:04")
-		event.SeatsLeft = event.Capacity - filled
-		events = append(events, event)
+		if len(page.Events) == limit {
+			last := page.Events[limit-1]
+			cursor := core.EventCursor{Sort: filters.Sort, Order: filters.Order, Value: lastSortValue, ID: last.EventID}
+			page.NextCursor = cursor.Encode()
+			break
+		}
+
+		// Render the date and times in the event's zone
+		event.Localize(eventLocation(event.TimeZone))
+		event.SeatsLeft = event.Capacity - filled - held
+		page.Events = append(page.Events, event)
+		lastSortValue = sortValue
 	}
 
-	return events, nil
+	eventIDs := make([]int, len(page.Events))
+	for i, event := range page.Events {
+		eventIDs[i] = event.EventID
+	}
+	tiers, err := loadTiers(er.db.db, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range page.Events {
+		page.Events[i].Tiers = tiers[page.Events[i].EventID]
+	}
+
+	return page, nil
 }
 
-// JoinEvent allows a customer to join an event by event ID
-func (er *EventRepo) JoinEvent(customerID int, eventID int) error {
-	// First get event details
-	var eventDate time.Time
-	var startTime, endTime time.Time
-	var capacity, filled int
+// JoinEvent books a seat for a customer in a single transaction. The event row
+// stays locked until commit, so concurrent joins can never oversell it.
+func (er *EventRepo) JoinEvent(customerID int, request *core.JoinEventRequest) error {
+	eventID := request.EventID
+
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
 
-	eventQuery := `SELECT event_date, start_time, end_time, capacity, filled FROM events_schema.events WHERE event_id = $1`
-	err := er.db.db.QueryRow(eventQuery, eventID).Scan(&eventDate, &startTime, &endTime, &capacity, &filled)
+	// Serialize joins of the same customer so two overlapping events cannot
+	// both pass the time conflict check
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock($1, $2)`, customerBookingLock, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to lock customer bookings: %v", err)
+	}
+
+	// Seats of lapsed holds are free again, even if the sweeper has not run yet
+	if _, err := releaseExpiredHolds(tx, eventID); err != nil {
+		return err
+	}
+
+	// Get and lock event details
+	var startsAt, endsAt time.Time
+	var capacity, filled, held, maxSeats int
+	var price int64
+	var status string
+
+	eventQuery := `SELECT starts_at, ends_at, capacity, filled, held, max_seats_per_booking, price, status FROM events_schema.events WHERE event_id = $1 FOR UPDATE`
+	err = tx.QueryRow(eventQuery, eventID).Scan(&startsAt, &endsAt, &capacity, &filled, &held, &maxSeats, &price, &status)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("event not found")
@@ -151,15 +392,52 @@ func (er *EventRepo) JoinEvent(customerID int, eventID int) error {
 		return fmt.Errorf("failed to get event details: %v", err)
 	}
 
-	// Check if event is full
-	if filled >= capacity {
-		return fmt.Errorf("event is full")
+	// Only published events take bookings
+	if status != core.EventStatusPublished {
+		return fmt.Errorf("event is not open for booking")
 	}
 
+	// Paid events are booked through a seat hold that is confirmed once paid
+	if price > 0 {
+		return core.ErrPaymentRequired
+	}
+
+	if request.Seats > maxSeats {
+		return fmt.Errorf("at most %d seats can be booked at once for this event", maxSeats)
+	}
+
+	// Check if the event has room for every seat asked for; held seats are taken
+	if filled+held+request.Seats > capacity {
+		return core.ErrEventFull
+	}
+
+	// Tiered events are full per tier as well
+	tier, err := getBookingTier(tx, eventID, request.TierID)
+	if err != nil {
+		return err
+	}
+	if tier != nil && tier.Filled+tier.Held+request.Seats > tier.Capacity {
+		return core.ErrEventFull
+	}
+
+	if err := insertBooking(tx, customerID, request, startsAt, endsAt, nil); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to join event: %v", err)
+	}
+
+	return nil
+}
+
+// insertBooking books the requested seats for a customer once the caller has
+// locked the event and made sure the seats are free. Payment is nil for free bookings.
+func insertBooking(tx *sql.Tx, customerID int, request *core.JoinEventRequest, startsAt, endsAt time.Time, payment *core.BookingPayment) error {
 	// Check for customer time conflicts
 	var hasConflict bool
-	conflictQuery := `SELECT events_schema.check_customer_time_conflict($1, $2, $3, $4)`
-	err = er.db.db.QueryRow(conflictQuery, customerID, eventDate, startTime, endTime).Scan(&hasConflict)
+	conflictQuery := `SELECT events_schema.check_customer_time_conflict($1, $2, $3)`
+	err := tx.QueryRow(conflictQuery, customerID, startsAt, endsAt).Scan(&hasConflict)
 	if err != nil {
 		return fmt.Errorf("failed to check time conflict: %v", err)
 	}
@@ -171,7 +449,7 @@ func (er *EventRepo) JoinEvent(customerID int, eventID int) error {
 	// Fetch customer details from users table
 	var customerEmail, customerUsername string
 	userQuery := `SELECT email, username FROM users WHERE cid = $1`
-	err = er.db.db.QueryRow(userQuery, customerID).Scan(&customerEmail, &customerUsername)
+	err = tx.QueryRow(userQuery, customerID).Scan(&customerEmail, &customerUsername)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("customer not found")
@@ -179,28 +457,45 @@ func (er *EventRepo) JoinEvent(customerID int, eventID int) error {
 		return fmt.Errorf("failed to get customer details: %v", err)
 	}
 
+	if payment == nil {
+		payment = &core.BookingPayment{Status: core.PaymentStatusNone, Currency: core.DefaultCurrency}
+	}
+
 	// Join the event with customer details
 	insertQuery := `
-		INSERT INTO events_schema.userbooked_events (event_id, cid, cemail, cusername)
-		VALUES ($1, $2, $3, $4)`
+		INSERT INTO events_schema.userbooked_events (event_id, cid, cemail, cusername, tier_id, seats, attendee_names, answers,
+			payment_status, payment_id, unit_price, amount_paid, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, $12, $13)`
 
-	_, err = er.db.db.Exec(insertQuery, eventID, customerID, customerEmail, customerUsername)
+	_, err = tx.Exec(insertQuery, request.EventID, customerID, customerEmail, customerUsername, nullableID(request.TierID),
+		request.Seats, pq.Array(request.AttendeeNames), encodeAnswers(request.Answers),
+		payment.Status, payment.PaymentID, payment.UnitPrice, payment.Amount, payment.Currency)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") {
 			return fmt.Errorf("you have already joined this event")
 		}
+		// The filled + held <= capacity CHECK is the last line of defence
+		if isCheckViolation(err) {
+			return core.ErrEventFull
+		}
 		return fmt.Errorf("failed to join event: %v", err)
 	}
 
+	// Customers who booked while queued for more seats stop waiting
+	deleteQuery := `DELETE FROM events_schema.event_waitlist WHERE event_id = $1 AND cid = $2`
+	if _, err := tx.Exec(deleteQuery, request.EventID, customerID); err != nil {
+		return fmt.Errorf("failed to leave waitlist: %v", err)
+	}
+
 	return nil
 }
 
 // GetEventCustomers gets all customers who have booked a specific event (organizer functionality)
 func (er *EventRepo) GetEventCustomers(eventID int, organizerID int) ([]core.CustomerBooking, error) {
-	// First verify the organizer owns this event
-	ownerQuery := `SELECT COUNT(*) FROM events_schema.events WHERE event_id = $1 AND organizer_id = $2`
+	// First verify the organizer is on the event's team
+	ownerQuery := `SELECT COUNT(*) FROM events_schema.events e WHERE e.event_id = $1 AND ` + teamAccess
 	var count int
-	err := er.db.db.QueryRow(ownerQuery, eventID, organizerID).Scan(&count)
+	err := er.db.db.QueryRow(ownerQuery, eventID, organizerID, rolesWith(core.PermissionView)).Scan(&count)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify event ownership: %v", err)
 	}
@@ -211,10 +506,11 @@ func (er *EventRepo) GetEventCustomers(eventID int, organizerID int) ([]core.Cus
 
 	// Get customers with user details from auth service
 	query := `
-		SELECT 
-			ub.cid, u.username, u.email, ub.booked_at
+		SELECT ` + customerBookingColumns + `
 		FROM events_schema.userbooked_events ub
+		JOIN events_schema.events e ON e.event_id = ub.event_id
 		JOIN users u ON ub.cid = u.cid
+		LEFT JOIN events_schema.ticket_tiers t ON t.tier_id = ub.tier_id
 		WHERE ub.event_id = $1
 		ORDER BY ub.booked_at ASC
 	`
@@ -227,26 +523,109 @@ func (er *EventRepo) GetEventCustomers(eventID int, organizerID int) ([]core.Cus
 
 	var customers []core.CustomerBooking
 	for rows.Next() {
-		var customer core.CustomerBooking
-		err := rows.Scan(&customer.CID, &customer.CUsername, &customer.CEmail, &customer.BookedAt)
+		customer, err := scanCustomerBooking(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan customer: %v", err)
 		}
-		customer.EventID = eventID // Set the event ID
 		customers = append(customers, customer)
 	}
 
 	return customers, nil
 }
 
-// GetOrganizerEvents retrieves all events created by an organizer
-func (er *EventRepo) GetOrganizerEvents(organizerID int) ([]core.Event, error) {
+// GetOrganizerCustomers gets the customers of all events whose team the
+// organizer is on, ordered by event start and booking time
+func (er *EventRepo) GetOrganizerCustomers(organizerID int) ([]core.CustomerBooking, error) {
 	query := `
-		SELECT event_id, event_name, organizer_id, place, event_date, start_time, end_time, 
-			   capacity, filled, created_at, updated_at
-		FROM events_schema.events WHERE organizer_id = $1 ORDER BY event_date, start_time`
+		SELECT ` + customerBookingColumns + `
+		FROM events_schema.userbooked_events ub
+		JOIN events_schema.events e ON e.event_id = ub.event_id
+		JOIN users u ON ub.cid = u.cid
+		LEFT JOIN events_schema.ticket_tiers t ON t.tier_id = ub.tier_id
+		JOIN events_schema.event_team tm ON tm.event_id = e.event_id AND tm.organizer_id = $1
+		ORDER BY e.starts_at, e.event_id, ub.booked_at
+	`
 
 	rows, err := er.db.db.Query(query, organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizer customers: %v", err)
+	}
+	defer rows.Close()
+
+	var customers []core.CustomerBooking
+	for rows.Next() {
+		customer, err := scanCustomerBooking(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan customer: %v", err)
+		}
+		customers = append(customers, customer)
+	}
+
+	return customers, nil
+}
+
+// customerBookingColumns is the column list read by scanCustomerBooking; queries
+// alias bookings as ub, events as e, users as u and ticket tiers as t
+const customerBookingColumns = `ub.event_id, e.event_name, ub.cid, u.username, u.email, COALESCE(t.name, ''), ub.seats,
+	ub.attendee_names, ub.answers, ub.status, ub.booked_at, ub.checked_in_at`
+
+// scanCustomerBooking reads the customerBookingColumns of a row
+func scanCustomerBooking(row rowScanner) (core.CustomerBooking, error) {
+	var customer core.CustomerBooking
+	var answers []byte
+	var checkedInAt sql.NullTime
+	err := row.Scan(&customer.EventID, &customer.EventName, &customer.CID, &customer.CUsername, &customer.CEmail,
+		&customer.Tier, &customer.Seats, pq.Array(&customer.AttendeeNames), &answers, &customer.Status,
+		&customer.BookedAt, &checkedInAt)
+	if err != nil {
+		return customer, err
+	}
+	if customer.Answers, err = decodeAnswers(answers); err != nil {
+		return customer, err
+	}
+	if checkedInAt.Valid {
+		customer.CheckedIn = true
+		customer.CheckedInAt = &checkedInAt.Time
+	}
+	return customer, nil
+}
+
+// encodeAnswers converts booking answers to the JSONB stored with a booking
+func encodeAnswers(answers map[string]string) []byte {
+	if len(answers) == 0 {
+		return []byte("{}")
+	}
+	encoded, _ := json.Marshal(answers) // A map of strings always encodes
+	return encoded
+}
+
+// decodeAnswers reads booking answers stored as JSONB; empty answers become nil
+func decodeAnswers(data []byte) (map[string]string, error) {
+	var answers map[string]string
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("failed to decode answers: %v", err)
+	}
+	if len(answers) == 0 {
+		return nil, nil
+	}
+	return answers, nil
+}
+
+// GetOrganizerEvents retrieves all events whose team the organizer is on, with
+// their role, optionally filtered by status
+func (er *EventRepo) GetOrganizerEvents(organizerID int, status string) ([]core.Event, error) {
+	query := `
+		SELECT ` + eventColumns + `, tm.role
+		FROM events_schema.events e
+		JOIN events_schema.event_team tm ON tm.event_id = e.event_id AND tm.organizer_id = $1`
+	args := []interface{}{organizerID}
+	if status != "" {
+		query += ` WHERE e.status = $2`
+		args = append(args, status)
+	}
+	query += ` ORDER BY e.starts_at`
+
+	rows, err := er.db.db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get organizer events: %v", err)
 	}
@@ -254,41 +633,27 @@ func (er *EventRepo) GetOrganizerEvents(organizerID int) ([]core.Event, error) {
 
 	var events []core.Event
 	for rows.Next() {
-		var event core.Event
-		var startTime, endTime time.Time // Scan TIME fields as time.Time
-		err := rows.Scan(
-			&event.EventID, &event.EventName, &event.OrganizerID,
-			&event.Place, &event.EventDate, &startTime, &endTime,
-			&event.Capacity, &event.Filled, &event.CreatedAt, &event.UpdatedAt,
-		)
+		var role string
+		event, err := scanEvent(rows, &role)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %v", err)
 		}
-		// Format the date and times for JSON response
-		event.EventDateStr = event.EventDate.Format("2006-01-02")
-		event.StartTime = startTime.Format("15:04")
-		event.EndTime = endTime.Format("15:04")
-		event.SeatsLeft = event.Capacity - event.Filled
+		event.TeamRole = role
 		events = append(events, event)
 	}
 
+	if err := er.attachTiers(events); err != nil {
+		return nil, err
+	}
+
 	return events, nil
 }
 
 // GetEventByID retrieves a specific event by ID
 func (er *EventRepo) GetEventByID(eventID int) (*core.Event, error) {
-	query := `
-		SELECT event_id, event_name, organizer_id, place, event_date, start_time, end_time, 
-			   capacity, filled, created_at, updated_at
-		FROM events_schema.events WHERE event_id = $1`
+	query := `SELECT ` + eventColumns + ` FROM events_schema.events e WHERE e.event_id = $1`
 
-	var event core.Event
-	var startTime, endTime time.Time // Scan TIME fields as time.Time
-	err := er.db.db.QueryRow(query, eventID).Scan(
-		&event.EventID, &event.EventName, &event.OrganizerID,
-		&event.Place, &event.EventDate, &startTime, &endTime,
-		&event.Capacity, &event.Filled, &event.CreatedAt, &event.UpdatedAt,
-	)
+	event, err := scanEvent(er.db.db.QueryRow(query, eventID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("event not found")
@@ -296,47 +661,183 @@ func (er *EventRepo) GetEventByID(eventID int) (*core.Event, error) {
 		return nil, fmt.Errorf("failed to get event: %v", err)
 	}
 
-	// Format the date and times for JSON response
-	event.EventDateStr = event.EventDate.Format("2006-01-02")
-	event.StartTime = startTime.Format("15:04")
-	event.EndTime = endTime.Format("15:04")
-	event.SeatsLeft = event.Capacity - event.Filled
+	tiers, err := loadTiers(er.db.db, []int{event.EventID})
+	if err != nil {
+		return nil, err
+	}
+	event.Tiers = tiers[event.EventID]
+
 	return &event, nil
 }
 
-// LeaveEvent allows a customer to leave an event
-func (er *EventRepo) LeaveEvent(customerID int, eventID int) error {
-	// First check if the customer is actually booked for this event
-	checkQuery := `SELECT COUNT(*) FROM events_schema.userbooked_events WHERE cid = $1 AND event_id = $2`
-	var count int
-	err := er.db.db.QueryRow(checkQuery, customerID, eventID).Scan(&count)
+// LeaveEvent releases seats of a customer's booking (customer functionality).
+// Releasing every seat, or passing 0, cancels the booking. Names beyond the
+// seats kept are dropped. The event's cancellation policy decides whether it
+// is too late to leave; late cancellations in flag mode are recorded with
+// their penalty, which is withheld from the refund of paid seats. Returns the
+// seats still booked and, for paid bookings, the refund queued for the
+// released seats.
+func (er *EventRepo) LeaveEvent(customerID int, eventID int, seats int) (*core.LeaveEventResponse, error) {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the event row so the freed seats can only be handed out once
+	var startsAt, now time.Time
+	var policy core.CancellationPolicy
+	var currency string
+	eventQuery := `
+		SELECT starts_at, cancellation_cutoff_minutes, cancellation_mode, late_cancellation_penalty, currency, NOW()
+		FROM events_schema.events WHERE event_id = $1 FOR UPDATE`
+	err = tx.QueryRow(eventQuery, eventID).Scan(&startsAt, &policy.CutoffMinutes, &policy.Mode, &policy.LatePenalty,
+		&currency, &now)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("event not found")
+		}
+		return nil, fmt.Errorf("failed to lock event: %v", err)
+	}
+
+	// Bookings cancelled by the organizer are kept for history
+	var bookingID, booked int
+	var checkedIn bool
+	var payment core.BookingPayment
+	bookingQuery := `
+		SELECT booking_id, seats, checked_in_at IS NOT NULL, payment_status, COALESCE(payment_id, ''), unit_price,
+			amount_paid, amount_refunded, currency
+		FROM events_schema.userbooked_events
+		WHERE cid = $1 AND event_id = $2 AND status = $3
+		FOR UPDATE`
+	err = tx.QueryRow(bookingQuery, customerID, eventID, core.BookingStatusConfirmed).Scan(&bookingID, &booked, &checkedIn,
+		&payment.Status, &payment.PaymentID, &payment.UnitPrice, &payment.Amount, &payment.Refunded, &payment.Currency)
 	if err != nil {
-		return fmt.Errorf("failed to check booking: %v", err)
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("you are not booked for this event")
+		}
+		return nil, fmt.Errorf("failed to check booking: %v", err)
 	}
 
-	if count == 0 {
-		return fmt.Errorf("you are not booked for this event")
+	if seats > booked {
+		return nil, fmt.Errorf("you only have %d seats booked for this event", booked)
 	}
 
-	// Remove the booking
-	deleteQuery := `DELETE FROM events_schema.userbooked_events WHERE cid = $1 AND event_id = $2`
-	_, err = er.db.db.Exec(deleteQuery, customerID, eventID)
+	// Doors may open before the start; admitted customers have attended
+	if checkedIn {
+		return nil, fmt.Errorf("you have already checked in to this event")
+	}
+
+	late, err := policy.CheckLeave(startsAt, now)
 	if err != nil {
-		return fmt.Errorf("failed to leave event: %v", err)
+		return nil, err
 	}
 
-	return nil
+	remaining := 0
+	if seats == 0 {
+		seats = booked
+	}
+
+	var penalty int64
+	if late {
+		penalty = policy.LatePenalty * int64(seats)
+	}
+
+	// Released seats are logged for analytics, as the booking may be deleted
+	var lateCancellation *core.LateCancellation
+	cancelQuery := `
+		INSERT INTO events_schema.booking_cancellations (event_id, cid, seats, cancelled_by, late, penalty, currency)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING cancellation_id, cancelled_at`
+	var cancellationID int
+	var cancelledAt time.Time
+	err = tx.QueryRow(cancelQuery, eventID, customerID, seats, core.CancelledByCustomer, late, penalty, currency).Scan(&cancellationID, &cancelledAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record cancellation: %v", err)
+	}
+	if late {
+		lateCancellation = &core.LateCancellation{
+			CancellationID: cancellationID,
+			EventID:        eventID,
+			CID:            customerID,
+			Seats:          seats,
+			Penalty:        penalty,
+			Currency:       currency,
+			CancelledAt:    cancelledAt,
+		}
+	}
+
+	// Paid seats are refunded at the price they were bought for. Leaving
+	// altogether returns whatever is left, so rounded discounts add up.
+	var refund *core.Refund
+	amount := min(payment.UnitPrice*int64(seats), payment.Amount-payment.Refunded)
+	if seats == booked {
+		amount = payment.Amount - payment.Refunded
+	}
+	// The late penalty is kept from what is refunded
+	if payment.Currency == currency {
+		amount -= min(penalty, amount)
+	}
+	if payment.PaymentID != "" && amount > 0 {
+		refund = &core.Refund{
+			EventID:   eventID,
+			CID:       customerID,
+			PaymentID: payment.PaymentID,
+			Amount:    amount,
+			Currency:  payment.Currency,
+			Reason:    core.RefundReasonCustomerCancelled,
+		}
+		if err := queueRefund(tx, refund); err != nil {
+			return nil, err
+		}
+		payment.Refunded += amount
+	}
+
+	if seats == booked {
+		deleteQuery := `DELETE FROM events_schema.userbooked_events WHERE booking_id = $1`
+		if _, err := tx.Exec(deleteQuery, bookingID); err != nil {
+			return nil, fmt.Errorf("failed to leave event: %v", err)
+		}
+	} else {
+		remaining = booked - seats
+		paymentStatus := payment.Status
+		if refund != nil {
+			paymentStatus = core.PaymentStatusPartiallyRefunded
+		}
+		updateQuery := `
+			UPDATE events_schema.userbooked_events
+			SET seats = $1, attendee_names = attendee_names[1:$1], amount_refunded = $2, payment_status = $3
+			WHERE booking_id = $4`
+		if _, err := tx.Exec(updateQuery, remaining, payment.Refunded, paymentStatus, bookingID); err != nil {
+			return nil, fmt.Errorf("failed to release seats: %v", err)
+		}
+	}
+
+	if err := promoteWaitlist(tx, eventID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to leave event: %v", err)
+	}
+
+	return &core.LeaveEventResponse{
+		EventID:          eventID,
+		Seats:            remaining,
+		Refund:           refund,
+		LateCancellation: lateCancellation,
+	}, nil
 }
 
-// GetUserBookings retrieves all events a user has booked
+// GetUserBookings retrieves all events a user has booked, including ones cancelled by the organizer
 func (er *EventRepo) GetUserBookings(userID int) ([]core.Event, error) {
 	query := `
-		SELECT e.event_id, e.event_name, e.organizer_id, e.place, e.event_date, 
-			   e.start_time, e.end_time, e.capacity, e.filled, e.created_at, e.updated_at
+		SELECT ` + eventColumns + `, ub.status, ub.seats, ub.payment_status, COALESCE(ub.payment_id, ''),
+			ub.unit_price, ub.amount_paid, ub.amount_refunded, ub.currency
 		FROM events_schema.events e
 		JOIN events_schema.userbooked_events ub ON e.event_id = ub.event_id
 		WHERE ub.cid = $1
-		ORDER BY e.event_date, e.start_time`
+		ORDER BY e.starts_at`
 
 	rows, err := er.db.db.Query(query, userID)
 	if err != nil {
@@ -346,21 +847,19 @@ func (er *EventRepo) GetUserBookings(userID int) ([]core.Event, error) {
 
 	var events []core.Event
 	for rows.Next() {
-		var event core.Event
-		var startTime, endTime time.Time // Scan TIME fields as time.Time
-		err := rows.Scan(
-			&event.EventID, &event.EventName, &event.OrganizerID,
-			&event.Place, &event.EventDate, &startTime, &endTime,
-			&event.Capacity, &event.Filled, &event.CreatedAt, &event.UpdatedAt,
-		)
+		var bookingStatus string
+		var bookedSeats int
+		var payment core.BookingPayment
+		event, err := scanEvent(rows, &bookingStatus, &bookedSeats, &payment.Status, &payment.PaymentID,
+			&payment.UnitPrice, &payment.Amount, &payment.Refunded, &payment.Currency)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan event: %v", err)
 		}
-		// Format the date and times for JSON response
-		event.EventDateStr = event.EventDate.Format("2006-01-02")
-		event.StartTime = startTime.Format("15:04")
-		event.EndTime = endTime.Format("15:04")
-		event.SeatsLeft = event.Capacity - event.Filled
+		event.BookingStatus = bookingStatus
+		event.BookedSeats = bookedSeats
+		if payment.Status != core.PaymentStatusNone {
+			event.Payment = &payment
+		}
 		events = append(events, event)
 	}
 
@@ -369,16 +868,65 @@ func (er *EventRepo) GetUserBookings(userID int) ([]core.Event, error) {
 
 // UpdateEvent updates an existing event (organizer functionality)
 func (er *EventRepo) UpdateEvent(eventID int, request *core.UpdateEventRequest, organizerID int) (*core.Event, error) {
-	// First verify the organizer owns this event
-	ownerQuery := `SELECT COUNT(*) FROM events_schema.events WHERE event_id = $1 AND organizer_id = $2`
-	var count int
-	err := er.db.db.QueryRow(ownerQuery, eventID, organizerID).Scan(&count)
+	events, err := er.UpdateEvents([]int{eventID}, request, organizerID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify event ownership: %v", err)
+		return nil, err
 	}
+	return &events[0], nil
+}
 
-	if count == 0 {
-		return nil, fmt.Errorf("event not found or you don't have permission to update it")
+// UpdateEvents applies the same update to several events in one transaction,
+// used when editing a range of a recurring series
+func (er *EventRepo) UpdateEvents(eventIDs []int, request *core.UpdateEventRequest, organizerID int) ([]core.Event, error) {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	for _, eventID := range eventIDs {
+		if err := updateEventTx(tx, eventID, request, organizerID); err != nil {
+			if len(eventIDs) > 1 {
+				return nil, fmt.Errorf("event %d: %w", eventID, err)
+			}
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to update event: %v", err)
+	}
+
+	// Return the updated events
+	events := make([]core.Event, 0, len(eventIDs))
+	for _, eventID := range eventIDs {
+		event, err := er.GetEventByID(eventID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+	return events, nil
+}
+
+// updateEventTx updates a single event inside the caller's transaction
+func updateEventTx(tx *sql.Tx, eventID int, request *core.UpdateEventRequest, organizerID int) error {
+	// First verify the organizer may edit this event, locking the row so
+	// concurrent joins cannot slip in before the waitlist is promoted
+	var currentFilled, currentCapacity, venueID, creatorID int
+	var startsAt, endsAt time.Time
+	var timeZone string
+	ownerQuery := `
+		SELECT e.filled + e.held, e.capacity, e.venue_id, e.starts_at, e.ends_at, e.time_zone, e.organizer_id
+		FROM events_schema.events e WHERE e.event_id = $1 AND ` + teamAccess + `
+		FOR UPDATE OF e`
+	err := tx.QueryRow(ownerQuery, eventID, organizerID, rolesWith(core.PermissionEdit)).Scan(&currentFilled,
+		&currentCapacity, &venueID, &startsAt, &endsAt, &timeZone, &creatorID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("event not found or you don't have permission to update it")
+		}
+		return fmt.Errorf("failed to verify event ownership: %v", err)
 	}
 
 	// Build dynamic update query
@@ -392,56 +940,64 @@ func (er *EventRepo) UpdateEvent(eventID int, request *core.UpdateEventRequest,
 		argIndex++
 	}
 
-	if request.Place != "" {
-		setParts = append(setParts, fmt.Sprintf("place = $%d", argIndex))
-		args = append(args, request.Place)
+	if request.Description != "" {
+		setParts = append(setParts, fmt.Sprintf("description = $%d", argIndex))
+		args = append(args, request.Description)
 		argIndex++
 	}
 
-	if request.EventDate != "" {
-		eventDate, err := time.Parse("2006-01-02", request.EventDate)
-		if err != nil {
-			return nil, fmt.Errorf("invalid date format. Use YYYY-MM-DD")
-		}
-		if eventDate.Before(time.Now().Truncate(24 * time.Hour)) {
-			return nil, fmt.Errorf("event date must be in the future")
-		}
-		setParts = append(setParts, fmt.Sprintf("event_date = $%d", argIndex))
-		args = append(args, eventDate)
-		argIndex++
+	// The event has to fit in its (possibly new) venue, chosen from the creator's venues
+	venue, err := resolveVenue(tx, creatorID, venueID, "")
+	if request.VenueID != 0 || request.Place != "" {
+		venue, err = resolveVenue(tx, creatorID, request.VenueID, request.Place)
+	}
+	if err != nil {
+		return err
+	}
+	if venue.VenueID != venueID {
+		setParts = append(setParts, fmt.Sprintf("venue_id = $%d, place = $%d", argIndex, argIndex+1))
+		args = append(args, venue.VenueID, venue.Name)
+		argIndex += 2
 	}
 
-	if request.StartTime != "" {
-		_, err := time.Parse("15:04", request.StartTime)
-		if err != nil {
-			return nil, fmt.Errorf("invalid start time format. Use HH:MM")
-		}
-		setParts = append(setParts, fmt.Sprintf("start_time = $%d", argIndex))
-		args = append(args, request.StartTime)
-		argIndex++
+	capacity := currentCapacity
+	if request.Capacity > 0 {
+		capacity = request.Capacity
+	}
+	if capacity > venue.MaxCapacity {
+		return fmt.Errorf("capacity (%d) exceeds the maximum capacity of venue '%s' (%d)", capacity, venue.Name, venue.MaxCapacity)
 	}
 
-	if request.EndTime != "" {
-		_, err := time.Parse("15:04", request.EndTime)
-		if err != nil {
-			return nil, fmt.Errorf("invalid end time format. Use HH:MM")
+	// Date and time fields are applied relative to the event's current range,
+	// as wall-clock times in its (possibly new) zone
+	from := eventLocation(timeZone)
+	to := from
+	if request.TimeZone != "" {
+		if to, err = core.LoadTimeZone(request.TimeZone); err != nil {
+			return err
 		}
-		setParts = append(setParts, fmt.Sprintf("end_time = $%d", argIndex))
-		args = append(args, request.EndTime)
+		setParts = append(setParts, fmt.Sprintf("time_zone = $%d", argIndex))
+		args = append(args, to.String())
 		argIndex++
 	}
 
-	if request.Capacity > 0 {
-		// Check if new capacity is less than current filled count
-		var currentFilled int
-		filledQuery := `SELECT filled FROM events_schema.events WHERE event_id = $1`
-		err := er.db.db.QueryRow(filledQuery, eventID).Scan(&currentFilled)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get current filled count: %v", err)
+	newStartsAt, newEndsAt, timeChanged, err := request.ApplyTimeUpdate(startsAt, endsAt, from, to)
+	if err != nil {
+		return err
+	}
+	if timeChanged {
+		if !newStartsAt.Equal(startsAt) && newStartsAt.Before(time.Now()) {
+			return fmt.Errorf("event must start in the future")
 		}
+		setParts = append(setParts, fmt.Sprintf("starts_at = $%d", argIndex), fmt.Sprintf("ends_at = $%d", argIndex+1))
+		args = append(args, newStartsAt, newEndsAt)
+		argIndex += 2
+	}
 
+	if request.Capacity > 0 {
+		// Check if new capacity is less than the seats booked or held
 		if request.Capacity < currentFilled {
-			return nil, fmt.Errorf("cannot set capacity (%d) lower than current bookings (%d)", request.Capacity, currentFilled)
+			return fmt.Errorf("cannot set capacity (%d) lower than current bookings and holds (%d)", request.Capacity, currentFilled)
 		}
 
 		setParts = append(setParts, fmt.Sprintf("capacity = $%d", argIndex))
@@ -449,8 +1005,35 @@ func (er *EventRepo) UpdateEvent(eventID int, request *core.UpdateEventRequest,
 		argIndex++
 	}
 
-	if len(setParts) == 0 {
-		return nil, fmt.Errorf("no fields to update")
+	// Lowering the limit leaves existing bookings as they are
+	if request.MaxSeatsPerBooking > 0 {
+		setParts = append(setParts, fmt.Sprintf("max_seats_per_booking = $%d", argIndex))
+		args = append(args, request.MaxSeatsPerBooking)
+		argIndex++
+	}
+
+	// Bookings keep the price they were paid at
+	if request.Price != nil {
+		setParts = append(setParts, fmt.Sprintf("price = $%d", argIndex))
+		args = append(args, *request.Price)
+		argIndex++
+	}
+	if request.Currency != "" {
+		setParts = append(setParts, fmt.Sprintf("currency = $%d", argIndex))
+		args = append(args, request.Currency)
+		argIndex++
+	}
+
+	// The policy applies to existing bookings from now on
+	if request.CancellationPolicy != nil {
+		setParts = append(setParts, fmt.Sprintf("cancellation_cutoff_minutes = $%d, cancellation_mode = $%d, late_cancellation_penalty = $%d",
+			argIndex, argIndex+1, argIndex+2))
+		args = append(args, request.CancellationPolicy.CutoffMinutes, request.CancellationPolicy.Mode, request.CancellationPolicy.LatePenalty)
+		argIndex += 3
+	}
+
+	if len(setParts) == 0 && len(request.Tiers) == 0 {
+		return fmt.Errorf("no fields to update")
 	}
 
 	// Add updated_at timestamp
@@ -458,26 +1041,83 @@ func (er *EventRepo) UpdateEvent(eventID int, request *core.UpdateEventRequest,
 	args = append(args, time.Now())
 	argIndex++
 
+	// Calendar apps replace their copy only when the sequence grows
+	setParts = append(setParts, "sequence = sequence + 1")
+
 	// Add WHERE clause parameters
 	args = append(args, eventID)
 
+	if len(request.Tiers) > 0 {
+		tiers, _, err := core.ParseTicketTiers(request.Tiers)
+		if err != nil {
+			return err
+		}
+		if err := syncTiers(tx, eventID, tiers); err != nil {
+			return err
+		}
+	}
+
 	updateQuery := fmt.Sprintf("UPDATE events_schema.events SET %s WHERE event_id = $%d", strings.Join(setParts, ", "), argIndex)
 
-	_, err = er.db.db.Exec(updateQuery, args...)
+	// Moving the event in time or to another venue may overlap a live event there
+	err = guardVenueOverlap(tx, venue.VenueID, newStartsAt, newEndsAt, eventID, func() error {
+		_, err := tx.Exec(updateQuery, args...)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update event: %v", err)
+		var venueErr *core.VenueUnavailableError
+		if errors.As(err, &venueErr) {
+			return err
+		}
+		return fmt.Errorf("failed to update event: %v", err)
 	}
 
-	// Return the updated event
-	return er.GetEventByID(eventID)
+	// A capacity increase may free seats for waitlisted customers
+	if request.Capacity > 0 {
+		if err := promoteWaitlist(tx, eventID); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // DeleteEvent deletes an event (organizer functionality)
 func (er *EventRepo) DeleteEvent(eventID int, organizerID int) error {
+	return er.DeleteEvents([]int{eventID}, organizerID)
+}
+
+// DeleteEvents deletes several events in one transaction, used when deleting
+// a range of a recurring series
+func (er *EventRepo) DeleteEvents(eventIDs []int, organizerID int) error {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	for _, eventID := range eventIDs {
+		if err := deleteEventTx(tx, eventID, organizerID); err != nil {
+			if len(eventIDs) > 1 {
+				return fmt.Errorf("event %d: %w", eventID, err)
+			}
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to delete event: %v", err)
+	}
+
+	return nil
+}
+
+// deleteEventTx deletes a single event inside the caller's transaction
+func deleteEventTx(tx *sql.Tx, eventID int, organizerID int) error {
 	// First verify the organizer owns this event
-	ownerQuery := `SELECT COUNT(*) FROM events_schema.events WHERE event_id = $1 AND organizer_id = $2`
+	ownerQuery := `SELECT COUNT(*) FROM events_schema.events e WHERE e.event_id = $1 AND ` + teamAccess
 	var count int
-	err := er.db.db.QueryRow(ownerQuery, eventID, organizerID).Scan(&count)
+	err := tx.QueryRow(ownerQuery, eventID, organizerID, rolesWith(core.PermissionManage)).Scan(&count)
 	if err != nil {
 		return fmt.Errorf("failed to verify event ownership: %v", err)
 	}
@@ -486,12 +1126,253 @@ func (er *EventRepo) DeleteEvent(eventID int, organizerID int) error {
 		return fmt.Errorf("event not found or you don't have permission to delete it")
 	}
 
-	// Delete the event (CASCADE will handle user bookings)
-	deleteQuery := `DELETE FROM events_schema.events WHERE event_id = $1`
-	_, err = er.db.db.Exec(deleteQuery, eventID)
+	// Delete the event (CASCADE will handle user bookings). Events with
+	// bookings must be cancelled instead so the history is kept.
+	deleteQuery := `
+		DELETE FROM events_schema.events e
+		WHERE e.event_id = $1
+		  AND (e.status = $2 OR NOT EXISTS (
+			SELECT 1 FROM events_schema.userbooked_events ub WHERE ub.event_id = e.event_id))`
+	result, err := tx.Exec(deleteQuery, eventID, core.EventStatusDraft)
+	if err != nil {
+		return fmt.Errorf("failed to delete event: %v", err)
+	}
+
+	removed, err := result.RowsAffected()
 	if err != nil {
 		return fmt.Errorf("failed to delete event: %v", err)
 	}
+	if removed == 0 {
+		return fmt.Errorf("event has bookings, cancel it instead of deleting it")
+	}
 
 	return nil
 }
+
+// SetEventStatus moves an event from one status to another. Cancelling also
+// marks its bookings as cancelled by the organizer, clears the waitlist and
+// seat holds and queues refunds of paid bookings.
+func (er *EventRepo) SetEventStatus(eventID int, from string, to string) error {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Conditional on the current status so concurrent transitions cannot both win
+	updateQuery := `UPDATE events_schema.events SET status = $1, sequence = sequence + 1, updated_at = NOW() WHERE event_id = $2 AND status = $3`
+	result, err := tx.Exec(updateQuery, to, eventID, from)
+	if err != nil {
+		return fmt.Errorf("failed to update event status: %v", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update event status: %v", err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("event status has changed, please retry")
+	}
+
+	if to == core.EventStatusCancelled {
+		bookingsQuery := `UPDATE events_schema.userbooked_events SET status = $1 WHERE event_id = $2 AND status = $3`
+		_, err = tx.Exec(bookingsQuery, core.BookingStatusCancelledByOrganizer, eventID, core.BookingStatusConfirmed)
+		if err != nil {
+			return fmt.Errorf("failed to cancel bookings: %v", err)
+		}
+
+		cancelQuery := `
+			INSERT INTO events_schema.booking_cancellations (event_id, cid, seats, cancelled_by)
+			SELECT event_id, cid, seats, $1 FROM events_schema.userbooked_events
+			WHERE event_id = $2 AND status = $3`
+		_, err = tx.Exec(cancelQuery, core.CancelledByOrganizer, eventID, core.BookingStatusCancelledByOrganizer)
+		if err != nil {
+			return fmt.Errorf("failed to record cancellations: %v", err)
+		}
+
+		waitlistQuery := `DELETE FROM events_schema.event_waitlist WHERE event_id = $1`
+		if _, err := tx.Exec(waitlistQuery, eventID); err != nil {
+			return fmt.Errorf("failed to clear waitlist: %v", err)
+		}
+
+		holdsQuery := `DELETE FROM events_schema.seat_holds WHERE event_id = $1`
+		if _, err := tx.Exec(holdsQuery, eventID); err != nil {
+			return fmt.Errorf("failed to release seat holds: %v", err)
+		}
+
+		// Whatever was paid and not yet refunded goes back to the customers
+		refundQuery := `
+			INSERT INTO events_schema.payment_refunds (event_id, cid, payment_id, amount, currency, reason)
+			SELECT event_id, cid, payment_id, amount_paid - amount_refunded, currency, $1
+			FROM events_schema.userbooked_events
+			WHERE event_id = $2 AND status = $3 AND payment_id IS NOT NULL AND amount_paid > amount_refunded`
+		_, err = tx.Exec(refundQuery, core.RefundReasonEventCancelled, eventID, core.BookingStatusCancelledByOrganizer)
+		if err != nil {
+			return fmt.Errorf("failed to queue refunds: %v", err)
+		}
+
+		paymentsQuery := `
+			UPDATE events_schema.userbooked_events
+			SET amount_refunded = amount_paid, payment_status = $1
+			WHERE event_id = $2 AND status = $3 AND payment_id IS NOT NULL AND amount_paid > amount_refunded`
+		_, err = tx.Exec(paymentsQuery, core.PaymentStatusRefunded, eventID, core.BookingStatusCancelledByOrganizer)
+		if err != nil {
+			return fmt.Errorf("failed to mark bookings refunded: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to update event status: %v", err)
+	}
+
+	return nil
+}
+
+// CompletePastEvents marks published events that have ended as completed
+func (er *EventRepo) CompletePastEvents() (int64, error) {
+	query := `
+		UPDATE events_schema.events SET status = $1
+		WHERE status = $2 AND ends_at < NOW()`
+	result, err := er.db.db.Exec(query, core.EventStatusCompleted, core.EventStatusPublished)
+	if err != nil {
+		return 0, fmt.Errorf("failed to complete past events: %v", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// distanceSQL returns the haversine distance in km between the venue v and the
+// point held in the latArg and lngArg parameters. Plain trigonometry keeps it
+// working without PostGIS or earthdistance.
+func distanceSQL(latArg, lngArg int) string {
+	return fmt.Sprintf(`(2 * %[3]v * asin(LEAST(1, sqrt(
+		power(sin(radians(v.latitude - $%[1]d) / 2), 2) +
+		cos(radians($%[1]d)) * cos(radians(v.latitude)) * power(sin(radians(v.longitude - $%[2]d) / 2), 2)))))`,
+		latArg, lngArg, core.EarthRadiusKm)
+}
+
+// prefixTSQuery turns free text into a tsquery matching every word as a
+// prefix, so "jazz fest" finds "Jazz Festival" while the user is typing.
+// Anything but letters and digits is dropped, which keeps to_tsquery from
+// failing on user input.
+func prefixTSQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > maxSearchTerms {
+		words = words[:maxSearchTerms]
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = word + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// guardVenueOverlap runs a statement that may violate the venue overlap
+// constraint under a savepoint, so a violation leaves the transaction usable
+// and is returned as a VenueUnavailableError naming the conflicting event
+func guardVenueOverlap(tx *sql.Tx, venueID int, startsAt, endsAt time.Time, excludeEventID int, run func() error) error {
+	if _, err := tx.Exec(`SAVEPOINT venue_overlap`); err != nil {
+		return fmt.Errorf("failed to create savepoint: %v", err)
+	}
+
+	runErr := run()
+	if !isExclusionViolation(runErr) {
+		if runErr != nil {
+			return runErr
+		}
+		if _, err := tx.Exec(`RELEASE SAVEPOINT venue_overlap`); err != nil {
+			return fmt.Errorf("failed to release savepoint: %v", err)
+		}
+		return nil
+	}
+
+	if _, err := tx.Exec(`ROLLBACK TO SAVEPOINT venue_overlap`); err != nil {
+		return fmt.Errorf("failed to roll back to savepoint: %v", err)
+	}
+
+	// The constraint waits for concurrent writers, so the conflicting row is committed by now
+	venueErr := &core.VenueUnavailableError{VenueID: venueID}
+	conflictQuery := `
+		SELECT event_id FROM events_schema.events
+		WHERE venue_id = $1 AND status <> $2 AND time_range && tstzrange($3, $4) AND event_id <> $5
+		ORDER BY starts_at
+		LIMIT 1`
+	err := tx.QueryRow(conflictQuery, venueID, core.EventStatusCancelled, startsAt, endsAt, excludeEventID).Scan(&venueErr.ConflictingEventID)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to find conflicting event: %v", err)
+	}
+
+	return venueErr
+}
+
+// isExclusionViolation reports whether err is a PostgreSQL exclusion constraint violation
+func isExclusionViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23P01"
+}
+
+// isCheckViolation reports whether err is a PostgreSQL CHECK constraint violation
+func isCheckViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23514"
+}
+
+// isUniqueViolation reports whether err is a PostgreSQL unique constraint violation
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// attachTiers loads the ticket tiers of the given events in one query
+func (er *EventRepo) attachTiers(events []core.Event) error {
+	eventIDs := make([]int, len(events))
+	for i, event := range events {
+		eventIDs[i] = event.EventID
+	}
+
+	tiers, err := loadTiers(er.db.db, eventIDs)
+	if err != nil {
+		return err
+	}
+	for i := range events {
+		events[i].Tiers = tiers[events[i].EventID]
+	}
+
+	return nil
+}
+
+// scanEvent reads the eventColumns of a row into an Event, followed by any
+// extra destinations selected after them
+func scanEvent(row rowScanner, extra ...interface{}) (core.Event, error) {
+	var event core.Event
+	var seriesID sql.NullInt64
+	dest := []interface{}{
+		&event.EventID, &event.EventName, &event.Description, &event.OrganizerID,
+		&event.VenueID, &event.Place, &event.StartsAt, &event.EndsAt, &event.TimeZone,
+		&event.Capacity, &event.Filled, &event.Held, &event.MaxSeatsPerBooking, &event.Price, &event.Currency,
+		&event.CancellationPolicy.CutoffMinutes, &event.CancellationPolicy.Mode, &event.CancellationPolicy.LatePenalty,
+		&event.Status, &seriesID, &event.Sequence, &event.CreatedAt, &event.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return event, err
+	}
+	event.SeriesID = int(seriesID.Int64)
+
+	// Format the date and times for JSON response
+	event.Localize(eventLocation(event.TimeZone))
+	event.SeatsLeft = event.Capacity - event.Filled - event.Held
+	return event, nil
+}
+
+// eventLocation loads an event's stored zone, falling back to UTC for names
+// the server's zone database does not know
+func eventLocation(name string) *time.Location {
+	loc, err := core.LoadTimeZone(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
@@ -0,0 +1,106 @@
+package persistance
+
+import (
+	"database/sql"
+	"eventservice/src/internal/core"
+	"fmt"
+	"time"
+)
+
+// CreateCalendarFeed stores the token hash of a user's new calendar feed
+func (er *EventRepo) CreateCalendarFeed(userID int, kind string, tokenHash string) (*core.CalendarFeed, error) {
+	query := `
+		INSERT INTO events_schema.calendar_feeds (user_id, kind, token_hash)
+		VALUES ($1, $2, $3)
+		RETURNING created_at`
+
+	feed := core.CalendarFeed{UserID: userID, Kind: kind}
+	if err := er.db.db.QueryRow(query, userID, kind, tokenHash).Scan(&feed.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("calendar feed already exists, rotate it to get a new URL")
+		}
+		return nil, fmt.Errorf("failed to create calendar feed: %v", err)
+	}
+
+	return &feed, nil
+}
+
+// RotateCalendarFeed replaces the token of a user's calendar feed, so the old URL stops working
+func (er *EventRepo) RotateCalendarFeed(userID int, kind string, tokenHash string) (*core.CalendarFeed, error) {
+	query := `
+		UPDATE events_schema.calendar_feeds
+		SET token_hash = $1, created_at = NOW(), last_accessed_at = NULL
+		WHERE user_id = $2 AND kind = $3
+		RETURNING created_at`
+
+	feed := core.CalendarFeed{UserID: userID, Kind: kind}
+	if err := er.db.db.QueryRow(query, tokenHash, userID, kind).Scan(&feed.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("calendar feed not found")
+		}
+		return nil, fmt.Errorf("failed to rotate calendar feed: %v", err)
+	}
+
+	return &feed, nil
+}
+
+// DeleteCalendarFeed revokes a user's calendar feed
+func (er *EventRepo) DeleteCalendarFeed(userID int, kind string) error {
+	query := `DELETE FROM events_schema.calendar_feeds WHERE user_id = $1 AND kind = $2`
+	result, err := er.db.db.Exec(query, userID, kind)
+	if err != nil {
+		return fmt.Errorf("failed to revoke calendar feed: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check revoke result: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("calendar feed not found")
+	}
+
+	return nil
+}
+
+// GetCalendarFeed retrieves a user's calendar feed, without its token
+func (er *EventRepo) GetCalendarFeed(userID int, kind string) (*core.CalendarFeed, error) {
+	query := `
+		SELECT created_at, last_accessed_at FROM events_schema.calendar_feeds
+		WHERE user_id = $1 AND kind = $2`
+
+	feed := core.CalendarFeed{UserID: userID, Kind: kind}
+	var lastAccessedAt sql.NullTime
+	if err := er.db.db.QueryRow(query, userID, kind).Scan(&feed.CreatedAt, &lastAccessedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("calendar feed not found")
+		}
+		return nil, fmt.Errorf("failed to get calendar feed: %v", err)
+	}
+	if lastAccessedAt.Valid {
+		feed.LastAccessedAt = &lastAccessedAt.Time
+	}
+
+	return &feed, nil
+}
+
+// GetCalendarFeedByToken finds the feed a token hash belongs to and records the access
+func (er *EventRepo) GetCalendarFeedByToken(tokenHash string) (*core.CalendarFeed, error) {
+	query := `
+		UPDATE events_schema.calendar_feeds SET last_accessed_at = NOW()
+		WHERE token_hash = $1
+		RETURNING user_id, kind, created_at, last_accessed_at`
+
+	var feed core.CalendarFeed
+	var lastAccessedAt time.Time
+	err := er.db.db.QueryRow(query, tokenHash).Scan(&feed.UserID, &feed.Kind, &feed.CreatedAt, &lastAccessedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("calendar feed not found")
+		}
+		return nil, fmt.Errorf("failed to get calendar feed: %v", err)
+	}
+	feed.LastAccessedAt = &lastAccessedAt
+
+	return &feed, nil
+}
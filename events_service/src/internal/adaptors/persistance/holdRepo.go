@@ -0,0 +1,402 @@
+package persistance
+
+import (
+	"database/sql"
+	"eventservice/src/internal/core"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// holdColumns is the column list read by scanSeatHold; queries alias seat_holds as h
+const holdColumns = `h.hold_id, h.event_id, e.event_name, h.cid, h.tier_id, h.seats, h.attendee_names, h.answers,
+	h.expires_at, h.created_at`
+
+// scanSeatHold reads the holdColumns of a row into a SeatHold
+func scanSeatHold(row rowScanner) (core.SeatHold, error) {
+	var hold core.SeatHold
+	var tierID sql.NullInt64
+	var answers []byte
+	err := row.Scan(&hold.HoldID, &hold.EventID, &hold.EventName, &hold.CID, &tierID, &hold.Seats,
+		pq.Array(&hold.AttendeeNames), &answers, &hold.ExpiresAt, &hold.CreatedAt)
+	if err != nil {
+		return hold, err
+	}
+	hold.TierID = int(tierID.Int64)
+	hold.Answers, err = decodeAnswers(answers)
+	return hold, err
+}
+
+// releaseExpiredHolds deletes the lapsed holds of an event and hands the freed
+// seats to its waitlist. It locks the event row first, like every other change
+// to its seats.
+func releaseExpiredHolds(tx *sql.Tx, eventID int) (int64, error) {
+	var lockedID int
+	err := tx.QueryRow(`SELECT event_id FROM events_schema.events WHERE event_id = $1 FOR UPDATE`, eventID).Scan(&lockedID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to lock event: %v", err)
+	}
+
+	deleteQuery := `DELETE FROM events_schema.seat_holds WHERE event_id = $1 AND expires_at <= NOW()`
+	result, err := tx.Exec(deleteQuery, eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to release expired holds: %v", err)
+	}
+
+	released, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check release result: %v", err)
+	}
+	if released > 0 {
+		if err := promoteWaitlist(tx, eventID); err != nil {
+			return 0, err
+		}
+	}
+
+	return released, nil
+}
+
+// CreateSeatHold reserves seats of an event for a customer for ttl. The checks
+// match JoinEvent, so a hold can be confirmed unless the customer books
+// something overlapping in the meantime.
+func (er *EventRepo) CreateSeatHold(customerID int, request *core.JoinEventRequest, ttl time.Duration) (*core.SeatHold, error) {
+	eventID := request.EventID
+
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Same lock order as JoinEvent: customer first, then the event row
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock($1, $2)`, customerBookingLock, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock customer bookings: %v", err)
+	}
+
+	if _, err := releaseExpiredHolds(tx, eventID); err != nil {
+		return nil, err
+	}
+
+	var eventName, status string
+	var startsAt, endsAt time.Time
+	var capacity, filled, held, maxSeats int
+	eventQuery := `
+		SELECT event_name, starts_at, ends_at, capacity, filled, held, max_seats_per_booking, status
+		FROM events_schema.events WHERE event_id = $1 FOR UPDATE`
+	err = tx.QueryRow(eventQuery, eventID).Scan(&eventName, &startsAt, &endsAt, &capacity, &filled, &held, &maxSeats, &status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event details: %v", err)
+	}
+
+	if status != core.EventStatusPublished {
+		return nil, fmt.Errorf("event is not open for booking")
+	}
+
+	if request.Seats > maxSeats {
+		return nil, fmt.Errorf("at most %d seats can be booked at once for this event", maxSeats)
+	}
+
+	if filled+held+request.Seats > capacity {
+		return nil, core.ErrEventFull
+	}
+
+	tier, err := getBookingTier(tx, eventID, request.TierID)
+	if err != nil {
+		return nil, err
+	}
+	if tier != nil && tier.Filled+tier.Held+request.Seats > tier.Capacity {
+		return nil, core.ErrEventFull
+	}
+
+	var booked int
+	bookedQuery := `SELECT COUNT(*) FROM events_schema.userbooked_events WHERE cid = $1 AND event_id = $2`
+	if err := tx.QueryRow(bookedQuery, customerID, eventID).Scan(&booked); err != nil {
+		return nil, fmt.Errorf("failed to check booking: %v", err)
+	}
+	if booked > 0 {
+		return nil, fmt.Errorf("you have already joined this event")
+	}
+
+	var hasConflict bool
+	conflictQuery := `SELECT events_schema.check_customer_time_conflict($1, $2, $3)`
+	err = tx.QueryRow(conflictQuery, customerID, startsAt, endsAt).Scan(&hasConflict)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check time conflict: %v", err)
+	}
+	if hasConflict {
+		return nil, fmt.Errorf("you already have an event during this time period")
+	}
+
+	hold := core.SeatHold{
+		EventID:       eventID,
+		EventName:     eventName,
+		CID:           customerID,
+		TierID:        request.TierID,
+		Seats:         request.Seats,
+		AttendeeNames: request.AttendeeNames,
+		Answers:       request.Answers,
+	}
+
+	// Expiry is computed by the database so every instance agrees on it
+	insertQuery := `
+		INSERT INTO events_schema.seat_holds (event_id, cid, tier_id, seats, attendee_names, answers, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW() + $7 * INTERVAL '1 second')
+		RETURNING hold_id, expires_at, created_at`
+	err = tx.QueryRow(insertQuery, eventID, customerID, nullableID(request.TierID), request.Seats,
+		pq.Array(request.AttendeeNames), encodeAnswers(request.Answers), ttl.Seconds()).Scan(
+		&hold.HoldID, &hold.ExpiresAt, &hold.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, fmt.Errorf("you already have a seat hold for this event")
+		}
+		if isCheckViolation(err) {
+			return nil, core.ErrEventFull
+		}
+		return nil, fmt.Errorf("failed to create seat hold: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to create seat hold: %v", err)
+	}
+
+	return &hold, nil
+}
+
+// GetUserSeatHolds lists a customer's holds that have not expired, soonest expiry first
+func (er *EventRepo) GetUserSeatHolds(customerID int) ([]core.SeatHold, error) {
+	query := `
+		SELECT ` + holdColumns + `
+		FROM events_schema.seat_holds h
+		JOIN events_schema.events e ON e.event_id = h.event_id
+		WHERE h.cid = $1 AND h.expires_at > NOW()
+		ORDER BY h.expires_at, h.hold_id`
+
+	rows, err := er.db.db.Query(query, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get seat holds: %v", err)
+	}
+	defer rows.Close()
+
+	var holds []core.SeatHold
+	for rows.Next() {
+		hold, err := scanSeatHold(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan seat hold: %v", err)
+		}
+		holds = append(holds, hold)
+	}
+
+	return holds, nil
+}
+
+// lockSeatHold locks a customer's hold together with its event, event first
+func lockSeatHold(tx *sql.Tx, customerID int, holdID int) (*core.SeatHold, error) {
+	var eventID int
+	eventQuery := `SELECT event_id FROM events_schema.seat_holds WHERE hold_id = $1 AND cid = $2`
+	if err := tx.QueryRow(eventQuery, holdID, customerID).Scan(&eventID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("seat hold not found")
+		}
+		return nil, fmt.Errorf("failed to get seat hold: %v", err)
+	}
+
+	var lockedID int
+	err := tx.QueryRow(`SELECT event_id FROM events_schema.events WHERE event_id = $1 FOR UPDATE`, eventID).Scan(&lockedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock event: %v", err)
+	}
+
+	query := `
+		SELECT ` + holdColumns + `
+		FROM events_schema.seat_holds h
+		JOIN events_schema.events e ON e.event_id = h.event_id
+		WHERE h.hold_id = $1 AND h.cid = $2
+		FOR UPDATE OF h`
+	hold, err := scanSeatHold(tx.QueryRow(query, holdID, customerID))
+	if err != nil {
+		// Released or swept between the two reads
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("seat hold not found")
+		}
+		return nil, fmt.Errorf("failed to get seat hold: %v", err)
+	}
+
+	return &hold, nil
+}
+
+// GetSeatHold returns one of a customer's holds, expired or not
+func (er *EventRepo) GetSeatHold(customerID int, holdID int) (*core.SeatHold, error) {
+	query := `
+		SELECT ` + holdColumns + `
+		FROM events_schema.seat_holds h
+		JOIN events_schema.events e ON e.event_id = h.event_id
+		WHERE h.hold_id = $1 AND h.cid = $2`
+	hold, err := scanSeatHold(er.db.db.QueryRow(query, holdID, customerID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("seat hold not found")
+		}
+		return nil, fmt.Errorf("failed to get seat hold: %v", err)
+	}
+	return &hold, nil
+}
+
+// ConfirmSeatHold turns a customer's hold into a booking of the same seats.
+// Holds on paid events need the payment taken for them. A promo code applied
+// to the payment is redeemed with the booking, or not at all.
+func (er *EventRepo) ConfirmSeatHold(customerID int, holdID int, payment *core.BookingPayment, redemption *core.PromoRedemption) (*core.SeatHold, error) {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock($1, $2)`, customerBookingLock, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock customer bookings: %v", err)
+	}
+
+	hold, err := lockSeatHold(tx, customerID, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired bool
+	if err := tx.QueryRow(`SELECT $1::TIMESTAMPTZ <= NOW()`, hold.ExpiresAt).Scan(&expired); err != nil {
+		return nil, fmt.Errorf("failed to check seat hold expiry: %v", err)
+	}
+	if expired {
+		return nil, fmt.Errorf("seat hold has expired")
+	}
+
+	event := core.Event{EventID: hold.EventID}
+	eventQuery := `SELECT organizer_id, status, starts_at, ends_at, price, currency FROM events_schema.events WHERE event_id = $1`
+	err = tx.QueryRow(eventQuery, hold.EventID).Scan(&event.OrganizerID, &event.Status, &event.StartsAt, &event.EndsAt,
+		&event.Price, &event.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event details: %v", err)
+	}
+	if event.Status != core.EventStatusPublished {
+		return nil, fmt.Errorf("event is not open for booking")
+	}
+	if event.Price > 0 && payment == nil {
+		return nil, core.ErrPaymentRequired
+	}
+
+	// Handing the held seats over keeps filled + held unchanged
+	if _, err := tx.Exec(`DELETE FROM events_schema.seat_holds WHERE hold_id = $1`, hold.HoldID); err != nil {
+		return nil, fmt.Errorf("failed to confirm seat hold: %v", err)
+	}
+
+	request := &core.JoinEventRequest{
+		EventID:       hold.EventID,
+		TierID:        hold.TierID,
+		Seats:         hold.Seats,
+		AttendeeNames: hold.AttendeeNames,
+		Answers:       hold.Answers,
+	}
+	if err := insertBooking(tx, customerID, request, event.StartsAt, event.EndsAt, payment); err != nil {
+		return nil, err
+	}
+
+	if redemption != nil {
+		if err := redeemPromoCode(tx, &event, redemption); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to confirm seat hold: %v", err)
+	}
+
+	return hold, nil
+}
+
+// ReleaseSeatHold gives up a customer's hold, handing its seats to the waitlist
+func (er *EventRepo) ReleaseSeatHold(customerID int, holdID int) error {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	hold, err := lockSeatHold(tx, customerID, holdID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM events_schema.seat_holds WHERE hold_id = $1`, hold.HoldID); err != nil {
+		return fmt.Errorf("failed to release seat hold: %v", err)
+	}
+
+	if err := promoteWaitlist(tx, hold.EventID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to release seat hold: %v", err)
+	}
+
+	return nil
+}
+
+// ReleaseExpiredSeatHolds releases every lapsed hold, one event per
+// transaction. Holds live in the database with their expiry, so holds that
+// lapsed while the service was down are released on the first run.
+func (er *EventRepo) ReleaseExpiredSeatHolds() (int64, error) {
+	rows, err := er.db.db.Query(`SELECT DISTINCT event_id FROM events_schema.seat_holds WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired holds: %v", err)
+	}
+
+	var eventIDs []int
+	for rows.Next() {
+		var eventID int
+		if err := rows.Scan(&eventID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan expired hold: %v", err)
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+	rows.Close()
+
+	var total int64
+	for _, eventID := range eventIDs {
+		released, err := er.releaseEventHolds(eventID)
+		if err != nil {
+			return total, err
+		}
+		total += released
+	}
+
+	return total, nil
+}
+
+// releaseEventHolds releases the lapsed holds of one event in its own transaction
+func (er *EventRepo) releaseEventHolds(eventID int) (int64, error) {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	released, err := releaseExpiredHolds(tx, eventID)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to release expired holds: %v", err)
+	}
+
+	return released, nil
+}
@@ -0,0 +1,54 @@
+package persistance
+
+import (
+	"eventservice/src/internal/core"
+	"fmt"
+)
+
+// ImportEvents inserts events in one transaction, each under its own savepoint
+// so a failing event is undone alone and reported in its result. Later events
+// see the earlier ones, so overlaps within the import are caught too. Once all
+// are tried, commit decides from the number of failures whether to keep them;
+// the returned bool reports whether the transaction was committed.
+func (er *EventRepo) ImportEvents(events []core.Event, commit func(failed int) bool) ([]core.ImportedEvent, bool, error) {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	results := make([]core.ImportedEvent, len(events))
+	failed := 0
+	for i := range events {
+		event := &events[i]
+		if _, err := tx.Exec(`SAVEPOINT import_event`); err != nil {
+			return nil, false, fmt.Errorf("failed to create savepoint: %v", err)
+		}
+
+		err := assignVenue(tx, event)
+		if err == nil {
+			results[i].EventID, err = insertEvent(tx, event)
+		}
+		if err != nil {
+			if _, rollbackErr := tx.Exec(`ROLLBACK TO SAVEPOINT import_event`); rollbackErr != nil {
+				return nil, false, fmt.Errorf("failed to roll back to savepoint: %v", rollbackErr)
+			}
+			results[i] = core.ImportedEvent{Err: err}
+			failed++
+			continue
+		}
+
+		if _, err := tx.Exec(`RELEASE SAVEPOINT import_event`); err != nil {
+			return nil, false, fmt.Errorf("failed to release savepoint: %v", err)
+		}
+	}
+
+	if !commit(failed) {
+		return results, false, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("failed to import events: %v", err)
+	}
+
+	return results, true, nil
+}
@@ -0,0 +1,167 @@
+package persistance
+
+import (
+	"database/sql"
+	"eventservice/src/internal/core"
+	"fmt"
+)
+
+// refundColumns is the column list read by scanRefund
+const refundColumns = `refund_id, event_id, cid, payment_id, amount, currency, reason, status,
+	COALESCE(provider_refund_id, ''), attempts, last_error, created_at, processed_at`
+
+// scanRefund reads the refundColumns of a row into a Refund
+func scanRefund(row rowScanner) (core.Refund, error) {
+	var refund core.Refund
+	var processedAt sql.NullTime
+	err := row.Scan(&refund.RefundID, &refund.EventID, &refund.CID, &refund.PaymentID, &refund.Amount, &refund.Currency,
+		&refund.Reason, &refund.Status, &refund.ProviderRefundID, &refund.Attempts, &refund.LastError,
+		&refund.CreatedAt, &processedAt)
+	if err != nil {
+		return refund, err
+	}
+	if processedAt.Valid {
+		refund.ProcessedAt = &processedAt.Time
+	}
+	return refund, nil
+}
+
+// queueRefund records a pending refund in the caller's transaction, filling in
+// its ID, status and creation time
+func queueRefund(tx *sql.Tx, refund *core.Refund) error {
+	query := `
+		INSERT INTO events_schema.payment_refunds (event_id, cid, payment_id, amount, currency, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING refund_id, status, created_at`
+	err := tx.QueryRow(query, refund.EventID, refund.CID, refund.PaymentID, refund.Amount, refund.Currency,
+		refund.Reason).Scan(&refund.RefundID, &refund.Status, &refund.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to queue refund: %v", err)
+	}
+	return nil
+}
+
+// QueueRefund records a pending refund of a payment that has no booking, such
+// as one taken for a hold that could not be confirmed
+func (er *EventRepo) QueueRefund(refund *core.Refund) (*core.Refund, error) {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := queueRefund(tx, refund); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to queue refund: %v", err)
+	}
+
+	return refund, nil
+}
+
+// GetPendingRefundIDs lists up to limit refunds still to be sent, oldest first
+func (er *EventRepo) GetPendingRefundIDs(limit int) ([]int, error) {
+	query := `SELECT refund_id FROM events_schema.payment_refunds WHERE status = $1 ORDER BY refund_id LIMIT $2`
+	rows, err := er.db.db.Query(query, core.RefundStatusPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending refunds: %v", err)
+	}
+	defer rows.Close()
+
+	var refundIDs []int
+	for rows.Next() {
+		var refundID int
+		if err := rows.Scan(&refundID); err != nil {
+			return nil, fmt.Errorf("failed to scan pending refund: %v", err)
+		}
+		refundIDs = append(refundIDs, refundID)
+	}
+
+	return refundIDs, nil
+}
+
+// ProcessRefund sends a pending refund with send and records the outcome. The
+// refund stays locked meanwhile, so concurrent workers skip it; a refund that
+// is locked or no longer pending is returned as is. Failed attempts are
+// retried until core.MaxRefundAttempts.
+func (er *EventRepo) ProcessRefund(refundID int, send func(refund *core.Refund) (*core.PaymentRefund, error)) (*core.Refund, error) {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		SELECT ` + refundColumns + ` FROM events_schema.payment_refunds
+		WHERE refund_id = $1 AND status = $2
+		FOR UPDATE SKIP LOCKED`
+	refund, err := scanRefund(tx.QueryRow(query, refundID, core.RefundStatusPending))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			current, err := scanRefund(er.db.db.QueryRow(`SELECT `+refundColumns+` FROM events_schema.payment_refunds WHERE refund_id = $1`, refundID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to get refund: %v", err)
+			}
+			return &current, nil
+		}
+		return nil, fmt.Errorf("failed to get refund: %v", err)
+	}
+
+	refund.Attempts++
+	providerRefund, sendErr := send(&refund)
+	if sendErr == nil {
+		refund.Status = core.RefundStatusSucceeded
+		refund.ProviderRefundID = providerRefund.ID
+		refund.LastError = ""
+	} else {
+		refund.LastError = sendErr.Error()
+		if refund.Attempts >= core.MaxRefundAttempts {
+			refund.Status = core.RefundStatusFailed
+		}
+	}
+
+	updateQuery := `
+		UPDATE events_schema.payment_refunds
+		SET status = $1, provider_refund_id = NULLIF($2, ''), attempts = $3, last_error = $4,
+			processed_at = CASE WHEN $1 = $5 THEN processed_at ELSE NOW() END
+		WHERE refund_id = $6
+		RETURNING processed_at`
+	var processedAt sql.NullTime
+	err = tx.QueryRow(updateQuery, refund.Status, refund.ProviderRefundID, refund.Attempts, refund.LastError,
+		core.RefundStatusPending, refund.RefundID).Scan(&processedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update refund: %v", err)
+	}
+	if processedAt.Valid {
+		refund.ProcessedAt = &processedAt.Time
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to update refund: %v", err)
+	}
+
+	return &refund, nil
+}
+
+// GetUserRefunds lists the refunds owed or paid to a customer, newest first
+func (er *EventRepo) GetUserRefunds(customerID int) ([]core.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM events_schema.payment_refunds WHERE cid = $1 ORDER BY created_at DESC, refund_id DESC`
+	rows, err := er.db.db.Query(query, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refunds: %v", err)
+	}
+	defer rows.Close()
+
+	var refunds []core.Refund
+	for rows.Next() {
+		refund, err := scanRefund(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan refund: %v", err)
+		}
+		refunds = append(refunds, refund)
+	}
+
+	return refunds, nil
+}
@@ -0,0 +1,204 @@
+package persistance
+
+import (
+	"database/sql"
+	"eventservice/src/internal/core"
+	"fmt"
+	"time"
+)
+
+// promoColumns is the column list of promo codes aliased p read by scanPromoCode
+const promoColumns = `p.promo_id, p.organizer_id, COALESCE(p.event_id, 0), p.code, p.kind, p.amount,
+	COALESCE(p.currency, ''), COALESCE(p.max_uses, 0), COALESCE(p.max_uses_per_customer, 0), p.uses,
+	p.valid_from, p.valid_until, p.active, p.created_at`
+
+// scanPromoCode reads the promoColumns of a row, then any extra columns, into a PromoCode
+func scanPromoCode(row rowScanner, extra ...interface{}) (core.PromoCode, error) {
+	var promo core.PromoCode
+	var validFrom, validUntil sql.NullTime
+	dest := []interface{}{&promo.PromoID, &promo.OrganizerID, &promo.EventID, &promo.Code, &promo.Kind, &promo.Amount,
+		&promo.Currency, &promo.MaxUses, &promo.MaxUsesPerCustomer, &promo.Uses,
+		&validFrom, &validUntil, &promo.Active, &promo.CreatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return promo, err
+	}
+	if validFrom.Valid {
+		promo.ValidFrom = &validFrom.Time
+	}
+	if validUntil.Valid {
+		promo.ValidUntil = &validUntil.Time
+	}
+	return promo, nil
+}
+
+// CreatePromoCode stores a new promo code. Codes are unique per organizer,
+// ignoring case.
+func (er *EventRepo) CreatePromoCode(promo *core.PromoCode) (*core.PromoCode, error) {
+	query := `
+		INSERT INTO events_schema.promo_codes (organizer_id, event_id, code, kind, amount, currency, max_uses,
+			max_uses_per_customer, valid_from, valid_until)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, 0), NULLIF($8, 0), $9, $10)
+		RETURNING promo_id, uses, active, created_at`
+	err := er.db.db.QueryRow(query, promo.OrganizerID, nullableID(promo.EventID), promo.Code, promo.Kind, promo.Amount,
+		promo.Currency, promo.MaxUses, promo.MaxUsesPerCustomer, nullableTime(promo.ValidFrom),
+		nullableTime(promo.ValidUntil)).Scan(&promo.PromoID, &promo.Uses, &promo.Active, &promo.CreatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("you already have a promo code '%s'", promo.Code)
+		}
+		return nil, fmt.Errorf("failed to create promo code: %v", err)
+	}
+	return promo, nil
+}
+
+// GetOrganizerPromoCodes lists the promo codes an organizer created, newest first
+func (er *EventRepo) GetOrganizerPromoCodes(organizerID int) ([]core.PromoCode, error) {
+	query := `
+		SELECT ` + promoColumns + ` FROM events_schema.promo_codes p
+		WHERE p.organizer_id = $1
+		ORDER BY p.created_at DESC, p.promo_id DESC`
+	rows, err := er.db.db.Query(query, organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promo codes: %v", err)
+	}
+	defer rows.Close()
+
+	var promos []core.PromoCode
+	for rows.Next() {
+		promo, err := scanPromoCode(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan promo code: %v", err)
+		}
+		promos = append(promos, promo)
+	}
+
+	return promos, nil
+}
+
+// DeactivatePromoCode stops an organizer's promo code from being applied.
+// Its redemptions are kept.
+func (er *EventRepo) DeactivatePromoCode(organizerID int, promoID int) error {
+	query := `UPDATE events_schema.promo_codes SET active = FALSE WHERE promo_id = $1 AND organizer_id = $2`
+	result, err := er.db.db.Exec(query, promoID, organizerID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate promo code: %v", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to deactivate promo code: %v", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("promo code not found")
+	}
+	return nil
+}
+
+// GetPromoCodeForEvent finds the code a customer entered for an event, along
+// with how often they have used it. A code of the event wins over an
+// organizer-wide code of the same name.
+func (er *EventRepo) GetPromoCodeForEvent(code string, eventID int, customerID int) (*core.PromoCode, int, error) {
+	query := `
+		SELECT ` + promoColumns + `,
+			(SELECT COUNT(*) FROM events_schema.promo_redemptions r WHERE r.promo_id = p.promo_id AND r.cid = $3)
+		FROM events_schema.promo_codes p
+		JOIN events_schema.events e ON e.event_id = $2
+		WHERE lower(p.code) = lower($1)
+			AND (p.event_id = e.event_id OR (p.event_id IS NULL AND p.organizer_id = e.organizer_id))
+		ORDER BY p.event_id NULLS LAST
+		LIMIT 1`
+	var customerUses int
+	promo, err := scanPromoCode(er.db.db.QueryRow(query, code, eventID, customerID), &customerUses)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, 0, fmt.Errorf("promo code not found")
+		}
+		return nil, 0, fmt.Errorf("failed to get promo code: %v", err)
+	}
+	return &promo, customerUses, nil
+}
+
+// redeemPromoCode records a promo code applied to a booking in the caller's
+// transaction. The code is locked and its limits checked again, so concurrent
+// bookings cannot use it past them.
+func redeemPromoCode(tx *sql.Tx, event *core.Event, redemption *core.PromoRedemption) error {
+	query := `SELECT ` + promoColumns + `, NOW() FROM events_schema.promo_codes p WHERE p.promo_id = $1 FOR UPDATE`
+	var now time.Time
+	promo, err := scanPromoCode(tx.QueryRow(query, redemption.PromoID), &now)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("promo code not found")
+		}
+		return fmt.Errorf("failed to lock promo code: %v", err)
+	}
+	if err := promo.CheckUsable(event, now); err != nil {
+		return err
+	}
+
+	var customerUses int
+	countQuery := `SELECT COUNT(*) FROM events_schema.promo_redemptions WHERE promo_id = $1 AND cid = $2`
+	if err := tx.QueryRow(countQuery, promo.PromoID, redemption.CID).Scan(&customerUses); err != nil {
+		return fmt.Errorf("failed to count promo code uses: %v", err)
+	}
+	if err := promo.CheckUses(customerUses); err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO events_schema.promo_redemptions (promo_id, event_id, cid, seats, subtotal, discount, amount_paid,
+			currency, payment_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''))
+		RETURNING redemption_id, redeemed_at`
+	err = tx.QueryRow(insertQuery, redemption.PromoID, redemption.EventID, redemption.CID, redemption.Seats,
+		redemption.Subtotal, redemption.Discount, redemption.AmountPaid, redemption.Currency,
+		redemption.PaymentID).Scan(&redemption.RedemptionID, &redemption.RedeemedAt)
+	if err != nil {
+		return fmt.Errorf("failed to redeem promo code: %v", err)
+	}
+
+	if _, err := tx.Exec(`UPDATE events_schema.promo_codes SET uses = uses + 1 WHERE promo_id = $1`, promo.PromoID); err != nil {
+		return fmt.Errorf("failed to redeem promo code: %v", err)
+	}
+
+	return nil
+}
+
+// GetPromoRedemptions lists the bookings an organizer's promo code was applied
+// to, newest first
+func (er *EventRepo) GetPromoRedemptions(organizerID int, promoID int) ([]core.PromoRedemption, error) {
+	var owner int
+	ownerQuery := `SELECT organizer_id FROM events_schema.promo_codes WHERE promo_id = $1`
+	if err := er.db.db.QueryRow(ownerQuery, promoID).Scan(&owner); err != nil || owner != organizerID {
+		if err != nil && err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to get promo code: %v", err)
+		}
+		return nil, fmt.Errorf("promo code not found")
+	}
+
+	query := `
+		SELECT r.redemption_id, r.promo_id, r.event_id, e.event_name, r.cid, COALESCE(u.username, ''), r.seats,
+			r.subtotal, r.discount, r.amount_paid, r.currency, COALESCE(r.payment_id, ''), r.redeemed_at
+		FROM events_schema.promo_redemptions r
+		JOIN events_schema.events e ON e.event_id = r.event_id
+		LEFT JOIN users u ON u.cid = r.cid
+		WHERE r.promo_id = $1
+		ORDER BY r.redeemed_at DESC, r.redemption_id DESC`
+	rows, err := er.db.db.Query(query, promoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promo redemptions: %v", err)
+	}
+	defer rows.Close()
+
+	var redemptions []core.PromoRedemption
+	for rows.Next() {
+		var redemption core.PromoRedemption
+		err := rows.Scan(&redemption.RedemptionID, &redemption.PromoID, &redemption.EventID, &redemption.EventName,
+			&redemption.CID, &redemption.CUsername, &redemption.Seats, &redemption.Subtotal, &redemption.Discount,
+			&redemption.AmountPaid, &redemption.Currency, &redemption.PaymentID, &redemption.RedeemedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan promo redemption: %v", err)
+		}
+		redemptions = append(redemptions, redemption)
+	}
+
+	return redemptions, nil
+}
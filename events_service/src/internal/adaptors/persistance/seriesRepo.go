@@ -0,0 +1,103 @@
+package persistance
+
+import (
+	"errors"
+	"eventservice/src/internal/core"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// CreateSeries stores a recurring series and materialises its occurrences in
+// one transaction. Occurrences whose venue is taken are reported as collisions.
+func (er *EventRepo) CreateSeries(series *core.EventSeries, occurrences []core.Event) (*core.SeriesResponse, error) {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	exdates := make([]string, len(series.ExDates))
+	for i, exdate := range series.ExDates {
+		exdates[i] = exdate.Format("2006-01-02")
+	}
+
+	seriesQuery := `
+		INSERT INTO events_schema.event_series (organizer_id, rrule, exdates)
+		VALUES ($1, $2, $3::DATE[])
+		RETURNING series_id`
+	err = tx.QueryRow(seriesQuery, series.OrganizerID, series.RRule, pq.Array(exdates)).Scan(&series.SeriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create series: %v", err)
+	}
+
+	result := &core.SeriesResponse{SeriesID: series.SeriesID, RRule: series.RRule}
+	var eventIDs []int
+	for i := range occurrences {
+		occurrence := &occurrences[i]
+		occurrence.SeriesID = series.SeriesID
+
+		if err := assignVenue(tx, occurrence); err != nil {
+			return nil, err
+		}
+
+		eventID, err := insertEvent(tx, occurrence)
+		var venueErr *core.VenueUnavailableError
+		if errors.As(err, &venueErr) {
+			result.Collisions = append(result.Collisions, core.SeriesCollision{
+				Date:               occurrence.StartsAt.In(eventLocation(occurrence.TimeZone)).Format(core.DateLayout),
+				Reason:             venueErr.Error(),
+				ConflictingEventID: venueErr.ConflictingEventID,
+			})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	if len(eventIDs) == 0 {
+		return nil, fmt.Errorf("venue '%s' is not available for any occurrence of the series", occurrences[0].Place)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to create series: %v", err)
+	}
+
+	for _, eventID := range eventIDs {
+		event, err := er.GetEventByID(eventID)
+		if err != nil {
+			return nil, err
+		}
+		result.Events = append(result.Events, *event)
+	}
+
+	return result, nil
+}
+
+// GetSeriesEventIDs lists the editable occurrences of a series starting at or after from
+func (er *EventRepo) GetSeriesEventIDs(seriesID int, from time.Time) ([]int, error) {
+	query := `
+		SELECT event_id FROM events_schema.events
+		WHERE series_id = $1 AND starts_at >= $2 AND status IN ($3, $4)
+		ORDER BY starts_at`
+
+	rows, err := er.db.db.Query(query, seriesID, from, core.EventStatusDraft, core.EventStatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series events: %v", err)
+	}
+	defer rows.Close()
+
+	var eventIDs []int
+	for rows.Next() {
+		var eventID int
+		if err := rows.Scan(&eventID); err != nil {
+			return nil, fmt.Errorf("failed to scan series event: %v", err)
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	return eventIDs, nil
+}
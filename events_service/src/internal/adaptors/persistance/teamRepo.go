@@ -0,0 +1,157 @@
+package persistance
+
+import (
+	"database/sql"
+	"eventservice/src/internal/core"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// teamAccess is a condition on events aliased e that holds when organizer $2
+// has one of the roles in $3 on the event; pass the roles with rolesWith
+const teamAccess = `EXISTS (
+			SELECT 1 FROM events_schema.event_team tm
+			WHERE tm.event_id = e.event_id AND tm.organizer_id = $2 AND tm.role = ANY($3))`
+
+// rolesWith is the teamAccess argument of the roles that grant permission
+func rolesWith(permission string) interface{} {
+	return pq.Array(core.RolesWithPermission(permission))
+}
+
+// addEventOwner puts the organizer who created an event on its team as owner
+func addEventOwner(tx *sql.Tx, eventID int, organizerID int) error {
+	query := `
+		INSERT INTO events_schema.event_team (event_id, organizer_id, role, added_by)
+		VALUES ($1, $2, $3, $2)`
+	if _, err := tx.Exec(query, eventID, organizerID, core.TeamRoleOwner); err != nil {
+		return fmt.Errorf("failed to add event owner: %v", err)
+	}
+	return nil
+}
+
+// GetEventRole returns the organizer's role on an event's team, or "" when they are not on it
+func (er *EventRepo) GetEventRole(eventID int, organizerID int) (string, error) {
+	query := `SELECT role FROM events_schema.event_team WHERE event_id = $1 AND organizer_id = $2`
+	var role string
+	err := er.db.db.QueryRow(query, eventID, organizerID).Scan(&role)
+	if err != nil && err != sql.ErrNoRows {
+		return "", fmt.Errorf("failed to get team role: %v", err)
+	}
+	return role, nil
+}
+
+// GetEventTeam lists the organizers on an event's team, owners first
+func (er *EventRepo) GetEventTeam(eventID int) ([]core.TeamMember, error) {
+	query := `
+		SELECT tm.event_id, tm.organizer_id, u.username, u.email, tm.role, tm.organizer_id = e.organizer_id,
+			tm.added_by, tm.added_at
+		FROM events_schema.event_team tm
+		JOIN events_schema.events e ON e.event_id = tm.event_id
+		JOIN users u ON u.cid = tm.organizer_id
+		WHERE tm.event_id = $1
+		ORDER BY array_position($2::TEXT[], tm.role), tm.added_at`
+
+	roles := []string{core.TeamRoleOwner, core.TeamRoleEditor, core.TeamRoleCheckIn, core.TeamRoleViewer}
+	rows, err := er.db.db.Query(query, eventID, pq.Array(roles))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event team: %v", err)
+	}
+	defer rows.Close()
+
+	var team []core.TeamMember
+	for rows.Next() {
+		var member core.TeamMember
+		err := rows.Scan(&member.EventID, &member.OrganizerID, &member.Username, &member.Email, &member.Role,
+			&member.Creator, &member.AddedBy, &member.AddedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan team member: %v", err)
+		}
+		team = append(team, member)
+	}
+
+	return team, nil
+}
+
+// AddTeamMember adds an organizer, found by ID or email, to an event's team
+func (er *EventRepo) AddTeamMember(eventID int, request *core.AddTeamMemberRequest, addedBy int) (*core.TeamMember, error) {
+	member := core.TeamMember{EventID: eventID, Role: request.Role, AddedBy: addedBy}
+	var profile string
+	var err error
+	userQuery := `SELECT cid, username, email, profile::TEXT FROM users WHERE `
+	if request.OrganizerID != 0 {
+		err = er.db.db.QueryRow(userQuery+`cid = $1`, request.OrganizerID).Scan(
+			&member.OrganizerID, &member.Username, &member.Email, &profile)
+	} else {
+		err = er.db.db.QueryRow(userQuery+`lower(email) = $1`, strings.ToLower(strings.TrimSpace(request.Email))).Scan(
+			&member.OrganizerID, &member.Username, &member.Email, &profile)
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("organizer not found")
+		}
+		return nil, fmt.Errorf("failed to get organizer details: %v", err)
+	}
+	if profile != "organizer" {
+		return nil, fmt.Errorf("only organizers can join an event team")
+	}
+
+	insertQuery := `
+		INSERT INTO events_schema.event_team (event_id, organizer_id, role, added_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING added_at`
+	err = er.db.db.QueryRow(insertQuery, eventID, member.OrganizerID, member.Role, addedBy).Scan(&member.AddedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("organizer is already on the team")
+		}
+		return nil, fmt.Errorf("failed to add team member: %v", err)
+	}
+
+	return &member, nil
+}
+
+// UpdateTeamMemberRole changes the role of a team member other than the event's creator
+func (er *EventRepo) UpdateTeamMemberRole(eventID int, organizerID int, role string) error {
+	query := `
+		UPDATE events_schema.event_team tm SET role = $3
+		FROM events_schema.events e
+		WHERE tm.event_id = $1 AND tm.organizer_id = $2 AND e.event_id = tm.event_id AND e.organizer_id <> tm.organizer_id`
+	result, err := er.db.db.Exec(query, eventID, organizerID, role)
+	if err != nil {
+		return fmt.Errorf("failed to update team member: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check update result: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("team member not found or is the event's creator")
+	}
+
+	return nil
+}
+
+// RemoveTeamMember takes an organizer other than the event's creator off its team
+func (er *EventRepo) RemoveTeamMember(eventID int, organizerID int) error {
+	query := `
+		DELETE FROM events_schema.event_team tm
+		USING events_schema.events e
+		WHERE tm.event_id = $1 AND tm.organizer_id = $2 AND e.event_id = tm.event_id AND e.organizer_id <> tm.organizer_id`
+	result, err := er.db.db.Exec(query, eventID, organizerID)
+	if err != nil {
+		return fmt.Errorf("failed to remove team member: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check remove result: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("team member not found or is the event's creator")
+	}
+
+	return nil
+}
@@ -0,0 +1,268 @@
+package persistance
+
+import (
+	"database/sql"
+	"eventservice/src/internal/core"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// GetBookingTicket retrieves the ticket of a customer's booking for an event, without its code
+func (er *EventRepo) GetBookingTicket(customerID int, eventID int) (*core.Ticket, error) {
+	query := `
+		SELECT ub.booking_id, e.event_id, e.event_name, e.place, e.starts_at, e.ends_at, e.time_zone,
+			ub.seats, ub.attendee_names, ub.status, ub.checked_in_at
+		FROM events_schema.userbooked_events ub
+		JOIN events_schema.events e ON e.event_id = ub.event_id
+		WHERE ub.cid = $1 AND ub.event_id = $2`
+
+	var ticket core.Ticket
+	var checkedInAt sql.NullTime
+	err := er.db.db.QueryRow(query, customerID, eventID).Scan(
+		&ticket.BookingID, &ticket.EventID, &ticket.EventName, &ticket.Place, &ticket.StartsAt, &ticket.EndsAt,
+		&ticket.TimeZone, &ticket.Seats, pq.Array(&ticket.AttendeeNames), &ticket.Status, &checkedInAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("you are not booked for this event")
+		}
+		return nil, fmt.Errorf("failed to get ticket: %v", err)
+	}
+	if checkedInAt.Valid {
+		ticket.CheckedInAt = &checkedInAt.Time
+	}
+
+	return &ticket, nil
+}
+
+// CheckInBooking admits the booking of a scanned ticket (organizer functionality).
+// The booking must belong to the event and not have been checked in before.
+func (er *EventRepo) CheckInBooking(bookingID int, eventID int, organizerID int) (*core.CheckIn, error) {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// First verify the organizer may check in attendees of this event
+	var status string
+	ownerQuery := `SELECT e.status FROM events_schema.events e WHERE e.event_id = $1 AND ` + teamAccess
+	err = tx.QueryRow(ownerQuery, eventID, organizerID, rolesWith(core.PermissionCheckIn)).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("event not found or you don't have permission to check in its attendees")
+		}
+		return nil, fmt.Errorf("failed to verify event ownership: %v", err)
+	}
+	if status != core.EventStatusPublished {
+		return nil, fmt.Errorf("cannot check in attendees of a %s event", status)
+	}
+
+	// Lock the booking so a ticket scanned at two doors is only admitted once
+	var checkIn core.CheckIn
+	var bookingStatus string
+	var checkedInAt sql.NullTime
+	bookingQuery := `
+		SELECT ub.booking_id, ub.event_id, ub.cid, ub.cusername, COALESCE(t.name, ''), ub.seats,
+			ub.attendee_names, ub.status, ub.checked_in_at
+		FROM events_schema.userbooked_events ub
+		LEFT JOIN events_schema.ticket_tiers t ON t.tier_id = ub.tier_id
+		WHERE ub.booking_id = $1
+		FOR UPDATE OF ub`
+	err = tx.QueryRow(bookingQuery, bookingID).Scan(
+		&checkIn.BookingID, &checkIn.EventID, &checkIn.CID, &checkIn.CUsername, &checkIn.Tier, &checkIn.Seats,
+		pq.Array(&checkIn.AttendeeNames), &bookingStatus, &checkedInAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("ticket not found, the booking may have been cancelled")
+		}
+		return nil, fmt.Errorf("failed to get booking: %v", err)
+	}
+
+	if checkIn.EventID != eventID {
+		return nil, fmt.Errorf("ticket is for another event")
+	}
+	if bookingStatus != core.BookingStatusConfirmed {
+		return nil, fmt.Errorf("booking was cancelled")
+	}
+	if checkedInAt.Valid {
+		return nil, &core.TicketUsedError{BookingID: bookingID, CheckedInAt: checkedInAt.Time}
+	}
+
+	updateQuery := `
+		UPDATE events_schema.userbooked_events SET checked_in_at = NOW(), checked_in_device = NULL
+		WHERE booking_id = $1
+		RETURNING checked_in_at`
+	if err := tx.QueryRow(updateQuery, bookingID).Scan(&checkIn.CheckedInAt); err != nil {
+		return nil, fmt.Errorf("failed to check in: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to check in: %v", err)
+	}
+
+	return &checkIn, nil
+}
+
+// GetCheckInManifest lists the confirmed bookings of an event for offline
+// check-in (organizer functionality). Ticket hashes are left to the caller.
+func (er *EventRepo) GetCheckInManifest(eventID int, organizerID int) (*core.Manifest, error) {
+	manifest := core.Manifest{EventID: eventID}
+	eventQuery := `
+		SELECT e.event_name, e.place, e.starts_at, e.ends_at, NOW()
+		FROM events_schema.events e
+		WHERE e.event_id = $1 AND ` + teamAccess
+	err := er.db.db.QueryRow(eventQuery, eventID, organizerID, rolesWith(core.PermissionCheckIn)).Scan(
+		&manifest.EventName, &manifest.Place, &manifest.StartsAt, &manifest.EndsAt, &manifest.GeneratedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("event not found or you don't have permission to check in its attendees")
+		}
+		return nil, fmt.Errorf("failed to verify event ownership: %v", err)
+	}
+
+	query := `
+		SELECT ub.booking_id, ub.cid, ub.cusername, COALESCE(t.name, ''), ub.seats, ub.attendee_names, ub.checked_in_at
+		FROM events_schema.userbooked_events ub
+		LEFT JOIN events_schema.ticket_tiers t ON t.tier_id = ub.tier_id
+		WHERE ub.event_id = $1 AND ub.status = $2
+		ORDER BY ub.booking_id`
+
+	rows, err := er.db.db.Query(query, eventID, core.BookingStatusConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookings: %v", err)
+	}
+	defer rows.Close()
+
+	manifest.Entries = []core.ManifestEntry{}
+	for rows.Next() {
+		var entry core.ManifestEntry
+		var checkedInAt sql.NullTime
+		err := rows.Scan(&entry.BookingID, &entry.CID, &entry.CUsername, &entry.Tier, &entry.Seats,
+			pq.Array(&entry.AttendeeNames), &checkedInAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan booking: %v", err)
+		}
+		if checkedInAt.Valid {
+			entry.CheckedInAt = &checkedInAt.Time
+		}
+		manifest.Entries = append(manifest.Entries, entry)
+	}
+
+	return &manifest, nil
+}
+
+// SyncCheckIns records check-ins a door device made offline (organizer
+// functionality). Each booking keeps its earliest check-in; later ones are
+// reported as duplicates and invalid ones as rejected. Results are ordered by
+// booking and time.
+func (er *EventRepo) SyncCheckIns(eventID int, organizerID int, deviceID string, checkIns []core.OfflineCheckIn) (*core.CheckInSyncResponse, error) {
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// First verify the organizer may check in attendees; devices may sync after the event ended
+	var status string
+	ownerQuery := `SELECT e.status FROM events_schema.events e WHERE e.event_id = $1 AND ` + teamAccess
+	err = tx.QueryRow(ownerQuery, eventID, organizerID, rolesWith(core.PermissionCheckIn)).Scan(&status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("event not found or you don't have permission to check in its attendees")
+		}
+		return nil, fmt.Errorf("failed to verify event ownership: %v", err)
+	}
+	if status != core.EventStatusPublished && status != core.EventStatusCompleted {
+		return nil, fmt.Errorf("cannot check in attendees of a %s event", status)
+	}
+
+	// Earlier check-ins of a booking within the batch are applied first
+	sorted := make([]core.OfflineCheckIn, len(checkIns))
+	copy(sorted, checkIns)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].BookingID != sorted[j].BookingID {
+			return sorted[i].BookingID < sorted[j].BookingID
+		}
+		return sorted[i].CheckedInAt.Before(sorted[j].CheckedInAt)
+	})
+
+	latest := time.Now().Add(core.MaxCheckInClockSkew)
+	sync := &core.CheckInSyncResponse{Results: make([]core.CheckInSyncResult, 0, len(sorted))}
+	for _, checkIn := range sorted {
+		result := core.CheckInSyncResult{BookingID: checkIn.BookingID}
+		if checkIn.CheckedInAt.IsZero() || checkIn.CheckedInAt.After(latest) {
+			result.Status = core.CheckInSyncRejected
+			result.Reason = "check-in time is missing or in the future"
+			sync.Rejected++
+			sync.Results = append(sync.Results, result)
+			continue
+		}
+
+		var bookingEventID int
+		var bookingStatus string
+		var recordedAt sql.NullTime
+		var recordedDevice sql.NullString
+		bookingQuery := `
+			SELECT event_id, status, checked_in_at, checked_in_device
+			FROM events_schema.userbooked_events
+			WHERE booking_id = $1
+			FOR UPDATE`
+		err := tx.QueryRow(bookingQuery, checkIn.BookingID).Scan(&bookingEventID, &bookingStatus, &recordedAt, &recordedDevice)
+		switch {
+		case err == sql.ErrNoRows:
+			result.Reason = "booking not found, it may have been cancelled"
+		case err != nil:
+			return nil, fmt.Errorf("failed to get booking: %v", err)
+		case bookingEventID != eventID:
+			result.Reason = "booking is for another event"
+		case bookingStatus != core.BookingStatusConfirmed:
+			result.Reason = "booking was cancelled"
+		}
+		if result.Reason != "" {
+			result.Status = core.CheckInSyncRejected
+			sync.Rejected++
+			sync.Results = append(sync.Results, result)
+			continue
+		}
+
+		// The earliest check-in stands
+		if recordedAt.Valid && !checkIn.CheckedInAt.Before(recordedAt.Time) {
+			result.Status = core.CheckInSyncDuplicate
+			result.CheckedInAt = &recordedAt.Time
+			result.Device = recordedDevice.String
+			sync.Duplicates++
+			sync.Conflicts++
+			sync.Results = append(sync.Results, result)
+			continue
+		}
+
+		updateQuery := `
+			UPDATE events_schema.userbooked_events SET checked_in_at = $1, checked_in_device = $2
+			WHERE booking_id = $3`
+		if _, err := tx.Exec(updateQuery, checkIn.CheckedInAt, deviceID, checkIn.BookingID); err != nil {
+			return nil, fmt.Errorf("failed to record check-in: %v", err)
+		}
+
+		checkedInAt := checkIn.CheckedInAt
+		result.Status = core.CheckInSyncApplied
+		result.CheckedInAt = &checkedInAt
+		result.Device = deviceID
+		if recordedAt.Valid {
+			result.ReplacedAt = &recordedAt.Time
+			sync.Conflicts++
+		}
+		sync.Applied++
+		sync.Results = append(sync.Results, result)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to sync check-ins: %v", err)
+	}
+
+	return sync, nil
+}
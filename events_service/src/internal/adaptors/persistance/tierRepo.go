@@ -0,0 +1,199 @@
+package persistance
+
+import (
+	"database/sql"
+	"eventservice/src/internal/core"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// tierColumns is the column list read by scanTier
+const tierColumns = `tier_id, event_id, name, description, capacity, filled, held, sales_start, sales_end`
+
+// querier is satisfied by both *sql.DB and *sql.Tx
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// scanTier reads the tierColumns of a row into a TicketTier
+func scanTier(row rowScanner) (core.TicketTier, error) {
+	var tier core.TicketTier
+	var salesStart, salesEnd sql.NullTime
+	err := row.Scan(&tier.TierID, &tier.EventID, &tier.Name, &tier.Description,
+		&tier.Capacity, &tier.Filled, &tier.Held, &salesStart, &salesEnd)
+	if err != nil {
+		return tier, err
+	}
+
+	if salesStart.Valid {
+		tier.SalesStart = &salesStart.Time
+	}
+	if salesEnd.Valid {
+		tier.SalesEnd = &salesEnd.Time
+	}
+	tier.SeatsLeft = tier.Capacity - tier.Filled - tier.Held
+	return tier, nil
+}
+
+// loadTiers fetches the ticket tiers of several events keyed by event ID
+func loadTiers(q querier, eventIDs []int) (map[int][]core.TicketTier, error) {
+	tiers := make(map[int][]core.TicketTier)
+	if len(eventIDs) == 0 {
+		return tiers, nil
+	}
+
+	query := `SELECT ` + tierColumns + ` FROM events_schema.ticket_tiers WHERE event_id = ANY($1) ORDER BY event_id, tier_id`
+	rows, err := q.Query(query, pq.Array(eventIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket tiers: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		tier, err := scanTier(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ticket tier: %v", err)
+		}
+		tiers[tier.EventID] = append(tiers[tier.EventID], tier)
+	}
+
+	return tiers, nil
+}
+
+// insertTiers creates the ticket tiers of a new event
+func insertTiers(tx *sql.Tx, eventID int, tiers []core.TicketTier) error {
+	insertQuery := `
+		INSERT INTO events_schema.ticket_tiers (event_id, name, description, capacity, sales_start, sales_end)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+
+	for _, tier := range tiers {
+		_, err := tx.Exec(insertQuery, eventID, tier.Name, tier.Description, tier.Capacity, tier.SalesStart, tier.SalesEnd)
+		if err != nil {
+			return fmt.Errorf("failed to create ticket tier '%s': %v", tier.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// syncTiers replaces an event's ticket tiers, matching existing ones by name.
+// Tiers with bookings can be resized down to their filled count but not removed.
+func syncTiers(tx *sql.Tx, eventID int, tiers []core.TicketTier) error {
+	rows, err := tx.Query(`SELECT `+tierColumns+` FROM events_schema.ticket_tiers WHERE event_id = $1 FOR UPDATE`, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket tiers: %v", err)
+	}
+
+	existing := make(map[string]core.TicketTier)
+	for rows.Next() {
+		tier, err := scanTier(rows)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan ticket tier: %v", err)
+		}
+		existing[strings.ToLower(tier.Name)] = tier
+	}
+	rows.Close()
+
+	// Bookings made without a tier would not be counted by any of them
+	if len(existing) == 0 {
+		var filled int
+		err := tx.QueryRow(`SELECT filled + held FROM events_schema.events WHERE event_id = $1`, eventID).Scan(&filled)
+		if err != nil {
+			return fmt.Errorf("failed to get current filled count: %v", err)
+		}
+		if filled > 0 {
+			return fmt.Errorf("cannot add ticket tiers to an event that already has bookings or seat holds")
+		}
+	}
+
+	for _, tier := range tiers {
+		current, ok := existing[strings.ToLower(tier.Name)]
+		if !ok {
+			if err := insertTiers(tx, eventID, []core.TicketTier{tier}); err != nil {
+				return err
+			}
+			continue
+		}
+		delete(existing, strings.ToLower(tier.Name))
+
+		if tier.Capacity < current.Filled+current.Held {
+			return fmt.Errorf("cannot set capacity of ticket tier '%s' (%d) lower than current bookings and holds (%d)",
+				current.Name, tier.Capacity, current.Filled+current.Held)
+		}
+
+		updateQuery := `
+			UPDATE events_schema.ticket_tiers
+			SET name = $1, description = $2, capacity = $3, sales_start = $4, sales_end = $5
+			WHERE tier_id = $6`
+		_, err := tx.Exec(updateQuery, tier.Name, tier.Description, tier.Capacity, tier.SalesStart, tier.SalesEnd, current.TierID)
+		if err != nil {
+			return fmt.Errorf("failed to update ticket tier '%s': %v", tier.Name, err)
+		}
+	}
+
+	// Whatever is left was omitted from the request
+	for _, tier := range existing {
+		var bookings int
+		err := tx.QueryRow(`SELECT COUNT(*) FROM events_schema.userbooked_events WHERE tier_id = $1`, tier.TierID).Scan(&bookings)
+		if err != nil {
+			return fmt.Errorf("failed to check ticket tier bookings: %v", err)
+		}
+		if bookings > 0 {
+			return fmt.Errorf("cannot remove ticket tier '%s' because it has bookings", tier.Name)
+		}
+
+		if _, err := tx.Exec(`DELETE FROM events_schema.ticket_tiers WHERE tier_id = $1`, tier.TierID); err != nil {
+			return fmt.Errorf("failed to remove ticket tier '%s': %v", tier.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// getBookingTier validates the tier chosen for a booking and locks it. It
+// returns nil for events without tiers.
+func getBookingTier(tx *sql.Tx, eventID int, tierID int) (*core.TicketTier, error) {
+	var tierCount int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM events_schema.ticket_tiers WHERE event_id = $1`, eventID).Scan(&tierCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket tiers: %v", err)
+	}
+
+	if tierCount == 0 {
+		if tierID != 0 {
+			return nil, fmt.Errorf("event has no ticket tiers")
+		}
+		return nil, nil
+	}
+	if tierID == 0 {
+		return nil, fmt.Errorf("ticket tier is required for this event")
+	}
+
+	query := `SELECT ` + tierColumns + ` FROM events_schema.ticket_tiers WHERE tier_id = $1 AND event_id = $2 FOR UPDATE`
+	tier, err := scanTier(tx.QueryRow(query, tierID, eventID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("ticket tier not found")
+		}
+		return nil, fmt.Errorf("failed to get ticket tier: %v", err)
+	}
+
+	if !tier.OnSale(time.Now()) {
+		return nil, fmt.Errorf("ticket tier '%s' is not on sale", tier.Name)
+	}
+
+	return &tier, nil
+}
+
+// nullableID maps a zero ID to SQL NULL
+func nullableID(id int) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
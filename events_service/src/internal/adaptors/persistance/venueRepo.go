@@ -0,0 +1,262 @@
+package persistance
+
+import (
+	"database/sql"
+	"eventservice/src/internal/core"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// venueColumns is the column list read by scanVenue
+const venueColumns = `venue_id, name, address, max_capacity, organizer_id, shared, latitude, longitude, created_at, updated_at`
+
+type VenueRepo struct {
+	db *Database
+}
+
+func NewVenueRepo(d *Database) VenueRepo {
+	return VenueRepo{db: d}
+}
+
+// CreateVenue creates a new venue (organizer functionality)
+func (vr *VenueRepo) CreateVenue(venue *core.Venue) (*core.Venue, error) {
+	query := `
+		INSERT INTO events_schema.venues (name, name_key, address, max_capacity, organizer_id, shared, latitude, longitude)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + venueColumns
+
+	created, err := scanVenue(vr.db.db.QueryRow(query, venue.Name, core.NormalizeVenueName(venue.Name),
+		venue.Address, venue.MaxCapacity, venue.OrganizerID, venue.Shared, venue.Latitude, venue.Longitude))
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, fmt.Errorf("a venue named '%s' already exists", venue.Name)
+		}
+		return nil, fmt.Errorf("failed to create venue: %v", err)
+	}
+
+	return &created, nil
+}
+
+// GetVenueByID retrieves a specific venue by ID
+func (vr *VenueRepo) GetVenueByID(venueID int) (*core.Venue, error) {
+	query := `SELECT ` + venueColumns + ` FROM events_schema.venues WHERE venue_id = $1`
+
+	venue, err := scanVenue(vr.db.db.QueryRow(query, venueID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("venue not found")
+		}
+		return nil, fmt.Errorf("failed to get venue: %v", err)
+	}
+
+	return &venue, nil
+}
+
+// GetVenues lists the venues visible to an organizer, or the shared ones
+func (vr *VenueRepo) GetVenues(filters *core.VenueFilters) ([]core.Venue, error) {
+	query := `SELECT ` + venueColumns + ` FROM events_schema.venues WHERE (shared OR organizer_id = $1)`
+	args := []interface{}{filters.OrganizerID}
+
+	if filters.Name != "" {
+		query += ` AND name_key LIKE $2`
+		args = append(args, "%"+core.NormalizeVenueName(filters.Name)+"%")
+	}
+	query += ` ORDER BY name_key, venue_id`
+
+	rows, err := vr.db.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venues: %v", err)
+	}
+	defer rows.Close()
+
+	var venues []core.Venue
+	for rows.Next() {
+		venue, err := scanVenue(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan venue: %v", err)
+		}
+		venues = append(venues, venue)
+	}
+
+	return venues, nil
+}
+
+// UpdateVenue updates a venue owned by the organizer. Renames are copied to
+// the place of its events.
+func (vr *VenueRepo) UpdateVenue(venueID int, request *core.UpdateVenueRequest, organizerID int) (*core.Venue, error) {
+	tx, err := vr.db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the venue so events cannot be added while the capacity is checked
+	ownerQuery := `SELECT ` + venueColumns + ` FROM events_schema.venues WHERE venue_id = $1 AND organizer_id = $2 FOR UPDATE`
+	current, err := scanVenue(tx.QueryRow(ownerQuery, venueID, organizerID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("venue not found or you don't have permission to update it")
+		}
+		return nil, fmt.Errorf("failed to verify venue ownership: %v", err)
+	}
+
+	// Build dynamic update query
+	var setParts []string
+	var args []interface{}
+	argIndex := 1
+
+	name := strings.Join(strings.Fields(request.Name), " ")
+	if name != "" {
+		setParts = append(setParts, fmt.Sprintf("name = $%d, name_key = $%d", argIndex, argIndex+1))
+		args = append(args, name, core.NormalizeVenueName(name))
+		argIndex += 2
+	}
+
+	if request.Address != "" {
+		setParts = append(setParts, fmt.Sprintf("address = $%d", argIndex))
+		args = append(args, strings.TrimSpace(request.Address))
+		argIndex++
+	}
+
+	if request.MaxCapacity > 0 {
+		// Upcoming events must still fit
+		var largest int
+		largestQuery := `
+			SELECT COALESCE(MAX(capacity), 0) FROM events_schema.events
+			WHERE venue_id = $1 AND status IN ($2, $3) AND ends_at > NOW()`
+		err := tx.QueryRow(largestQuery, venueID, core.EventStatusDraft, core.EventStatusPublished).Scan(&largest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check venue events: %v", err)
+		}
+		if request.MaxCapacity < largest {
+			return nil, fmt.Errorf("cannot set max capacity (%d) lower than the capacity of an upcoming event (%d)",
+				request.MaxCapacity, largest)
+		}
+
+		setParts = append(setParts, fmt.Sprintf("max_capacity = $%d", argIndex))
+		args = append(args, request.MaxCapacity)
+		argIndex++
+	}
+
+	if request.Shared != nil {
+		// Other organizers' events keep the venue in use
+		if current.Shared && !*request.Shared {
+			var others int
+			othersQuery := `SELECT COUNT(*) FROM events_schema.events WHERE venue_id = $1 AND organizer_id <> $2`
+			if err := tx.QueryRow(othersQuery, venueID, organizerID).Scan(&others); err != nil {
+				return nil, fmt.Errorf("failed to check venue events: %v", err)
+			}
+			if others > 0 {
+				return nil, fmt.Errorf("venue is used by other organizers and must stay shared")
+			}
+		}
+
+		setParts = append(setParts, fmt.Sprintf("shared = $%d", argIndex))
+		args = append(args, *request.Shared)
+		argIndex++
+	}
+
+	if request.Latitude != nil {
+		setParts = append(setParts, fmt.Sprintf("latitude = $%d, longitude = $%d", argIndex, argIndex+1))
+		args = append(args, *request.Latitude, *request.Longitude)
+		argIndex += 2
+	}
+
+	if len(setParts) == 0 {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
+	args = append(args, venueID)
+	updateQuery := fmt.Sprintf("UPDATE events_schema.venues SET %s WHERE venue_id = $%d RETURNING %s",
+		strings.Join(setParts, ", "), argIndex, venueColumns)
+
+	updated, err := scanVenue(tx.QueryRow(updateQuery, args...))
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, fmt.Errorf("a venue named '%s' already exists", name)
+		}
+		return nil, fmt.Errorf("failed to update venue: %v", err)
+	}
+
+	if updated.Name != current.Name {
+		placeQuery := `UPDATE events_schema.events SET place = $1, updated_at = $2, sequence = sequence + 1 WHERE venue_id = $3`
+		if _, err := tx.Exec(placeQuery, updated.Name, time.Now(), venueID); err != nil {
+			return nil, fmt.Errorf("failed to rename venue on events: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to update venue: %v", err)
+	}
+
+	return &updated, nil
+}
+
+// DeleteVenue deletes a venue owned by the organizer that no event uses
+func (vr *VenueRepo) DeleteVenue(venueID int, organizerID int) error {
+	deleteQuery := `DELETE FROM events_schema.venues WHERE venue_id = $1 AND organizer_id = $2`
+	result, err := vr.db.db.Exec(deleteQuery, venueID, organizerID)
+	if err != nil {
+		// events.venue_id has no ON DELETE action
+		if strings.Contains(err.Error(), "foreign key") {
+			return fmt.Errorf("venue is used by events and cannot be deleted")
+		}
+		return fmt.Errorf("failed to delete venue: %v", err)
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to delete venue: %v", err)
+	}
+	if removed == 0 {
+		return fmt.Errorf("venue not found or you don't have permission to delete it")
+	}
+
+	return nil
+}
+
+// resolveVenue finds the venue an event is held at, by ID or else by name
+// among the organizer's own and the shared venues, and locks it against
+// capacity changes. The organizer must be allowed to use it.
+func resolveVenue(tx *sql.Tx, organizerID int, venueID int, place string) (*core.Venue, error) {
+	var row *sql.Row
+	if venueID != 0 {
+		query := `SELECT ` + venueColumns + ` FROM events_schema.venues WHERE venue_id = $1 FOR SHARE`
+		row = tx.QueryRow(query, venueID)
+	} else {
+		// Prefer the organizer's own venue over a shared one of the same name
+		query := `
+			SELECT ` + venueColumns + ` FROM events_schema.venues
+			WHERE name_key = $1 AND (organizer_id = $2 OR shared)
+			ORDER BY (organizer_id = $2) DESC
+			LIMIT 1
+			FOR SHARE`
+		row = tx.QueryRow(query, core.NormalizeVenueName(place), organizerID)
+	}
+
+	venue, err := scanVenue(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			if venueID != 0 {
+				return nil, fmt.Errorf("venue not found")
+			}
+			return nil, fmt.Errorf("venue '%s' not found, create it first", strings.TrimSpace(place))
+		}
+		return nil, fmt.Errorf("failed to get venue: %v", err)
+	}
+
+	if !venue.Shared && venue.OrganizerID != organizerID {
+		return nil, fmt.Errorf("venue not found")
+	}
+
+	return &venue, nil
+}
+
+// scanVenue reads the venueColumns of a row into a Venue
+func scanVenue(row rowScanner) (core.Venue, error) {
+	var venue core.Venue
+	err := row.Scan(&venue.VenueID, &venue.Name, &venue.Address, &venue.MaxCapacity,
+		&venue.OrganizerID, &venue.Shared, &venue.Latitude, &venue.Longitude, &venue.CreatedAt, &venue.UpdatedAt)
+	return venue, err
+}
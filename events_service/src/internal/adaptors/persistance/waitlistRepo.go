@@ -0,0 +1,393 @@
+package persistance
+
+import (
+	"database/sql"
+	"eventservice/src/internal/core"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// JoinWaitlist queues a customer for a full event (customer functionality).
+// On paid events promotion puts the customer into a seat hold to pay for.
+func (er *EventRepo) JoinWaitlist(customerID int, request *core.JoinEventRequest) (*core.WaitlistEntry, error) {
+	eventID := request.EventID
+
+	tx, err := er.db.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %v", err)
+	}
+	defer tx.Rollback()
+
+	// Same lock order as JoinEvent: customer first, then the event row
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock($1, $2)`, customerBookingLock, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock customer bookings: %v", err)
+	}
+
+	// Lock the event row so enqueueing and promotion are serialized per event
+	var eventName, status string
+	var startsAt, endsAt time.Time
+	var maxSeats int
+	eventQuery := `SELECT event_name, starts_at, ends_at, max_seats_per_booking, status FROM events_schema.events WHERE event_id = $1 FOR UPDATE`
+	err = tx.QueryRow(eventQuery, eventID).Scan(&eventName, &startsAt, &endsAt, &maxSeats, &status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("event not found")
+		}
+		return nil, fmt.Errorf("failed to get event details: %v", err)
+	}
+
+	if status != core.EventStatusPublished {
+		return nil, fmt.Errorf("event is not open for booking")
+	}
+
+	if request.Seats > maxSeats {
+		return nil, fmt.Errorf("at most %d seats can be booked at once for this event", maxSeats)
+	}
+
+	// Customers queue for a specific tier on tiered events
+	if _, err := getBookingTier(tx, eventID, request.TierID); err != nil {
+		return nil, err
+	}
+
+	// A customer who already holds a seat has nothing to wait for
+	var booked int
+	bookedQuery := `SELECT COUNT(*) FROM events_schema.userbooked_events WHERE cid = $1 AND event_id = $2`
+	err = tx.QueryRow(bookedQuery, customerID, eventID).Scan(&booked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check booking: %v", err)
+	}
+	if booked > 0 {
+		return nil, fmt.Errorf("you have already joined this event")
+	}
+
+	// Check for customer time conflicts
+	var hasConflict bool
+	conflictQuery := `SELECT events_schema.check_customer_time_conflict($1, $2, $3)`
+	err = tx.QueryRow(conflictQuery, customerID, startsAt, endsAt).Scan(&hasConflict)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check time conflict: %v", err)
+	}
+
+	if hasConflict {
+		return nil, fmt.Errorf("you already have an event during this time period")
+	}
+
+	// Fetch customer details from users table
+	var customerEmail, customerUsername string
+	userQuery := `SELECT email, username FROM users WHERE cid = $1`
+	err = tx.QueryRow(userQuery, customerID).Scan(&customerEmail, &customerUsername)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("customer not found")
+		}
+		return nil, fmt.Errorf("failed to get customer details: %v", err)
+	}
+
+	entry := core.WaitlistEntry{
+		EventID:   eventID,
+		EventName: eventName,
+		CID:       customerID,
+		CEmail:    customerEmail,
+		CUsername: customerUsername,
+		Seats:     request.Seats,
+	}
+
+	insertQuery := `
+		INSERT INTO events_schema.event_waitlist (event_id, cid, cemail, cusername, tier_id, seats, attendee_names, answers)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING joined_at`
+
+	err = tx.QueryRow(insertQuery, eventID, customerID, customerEmail, customerUsername,
+		nullableID(request.TierID), request.Seats, pq.Array(request.AttendeeNames), encodeAnswers(request.Answers)).Scan(&entry.JoinedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, fmt.Errorf("you are already on the waitlist for this event")
+		}
+		return nil, fmt.Errorf("failed to join waitlist: %v", err)
+	}
+
+	// Seats may have been freed between the failed join and taking the lock
+	if err := promoteWaitlist(tx, eventID); err != nil {
+		return nil, err
+	}
+
+	positionQuery := `
+		SELECT COUNT(*) FROM events_schema.event_waitlist w
+		JOIN events_schema.event_waitlist me ON me.event_id = w.event_id AND me.cid = $2
+		WHERE w.event_id = $1
+		  AND (w.joined_at, w.waitlist_id) <= (me.joined_at, me.waitlist_id)`
+	err = tx.QueryRow(positionQuery, eventID, customerID).Scan(&entry.Position)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waitlist position: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to join waitlist: %v", err)
+	}
+
+	return &entry, nil
+}
+
+// LeaveWaitlist removes a customer from an event's waitlist
+func (er *EventRepo) LeaveWaitlist(customerID int, eventID int) error {
+	deleteQuery := `DELETE FROM events_schema.event_waitlist WHERE cid = $1 AND event_id = $2`
+	result, err := er.db.db.Exec(deleteQuery, customerID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to leave waitlist: %v", err)
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to leave waitlist: %v", err)
+	}
+	if removed == 0 {
+		return fmt.Errorf("you are not on the waitlist for this event")
+	}
+
+	return nil
+}
+
+// GetUserWaitlist retrieves every waitlist a customer is queued on with their position
+func (er *EventRepo) GetUserWaitlist(userID int) ([]core.WaitlistEntry, error) {
+	query := `
+		SELECT w.event_id, e.event_name, w.cid, w.cemail, w.cusername, w.seats, w.position, w.joined_at
+		FROM (
+			SELECT event_id, cid, cemail, cusername, seats, joined_at,
+				   ROW_NUMBER() OVER (PARTITION BY event_id ORDER BY joined_at, waitlist_id) AS position
+			FROM events_schema.event_waitlist
+		) w
+		JOIN events_schema.events e ON e.event_id = w.event_id
+		WHERE w.cid = $1
+		ORDER BY w.joined_at`
+
+	rows, err := er.db.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user waitlist: %v", err)
+	}
+	defer rows.Close()
+
+	var entries []core.WaitlistEntry
+	for rows.Next() {
+		var entry core.WaitlistEntry
+		err := rows.Scan(&entry.EventID, &entry.EventName, &entry.CID, &entry.CEmail,
+			&entry.CUsername, &entry.Seats, &entry.Position, &entry.JoinedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan waitlist entry: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// GetEventWaitlist retrieves the waitlist of an event in queue order (organizer functionality)
+func (er *EventRepo) GetEventWaitlist(eventID int, organizerID int) ([]core.WaitlistEntry, error) {
+	// First verify the organizer is on the event's team
+	ownerQuery := `SELECT COUNT(*) FROM events_schema.events e WHERE e.event_id = $1 AND ` + teamAccess
+	var count int
+	err := er.db.db.QueryRow(ownerQuery, eventID, organizerID, rolesWith(core.PermissionView)).Scan(&count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify event ownership: %v", err)
+	}
+
+	if count == 0 {
+		return nil, fmt.Errorf("event not found or you don't have permission to view its waitlist")
+	}
+
+	query := `
+		SELECT cid, cemail, cusername, seats, joined_at
+		FROM events_schema.event_waitlist
+		WHERE event_id = $1
+		ORDER BY joined_at, waitlist_id`
+
+	rows, err := er.db.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event waitlist: %v", err)
+	}
+	defer rows.Close()
+
+	var entries []core.WaitlistEntry
+	for rows.Next() {
+		var entry core.WaitlistEntry
+		err := rows.Scan(&entry.CID, &entry.CEmail, &entry.CUsername, &entry.Seats, &entry.JoinedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan waitlist entry: %v", err)
+		}
+		entry.EventID = eventID
+		entry.Position = len(entries) + 1
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// promoteWaitlist moves customers from the head of an event's waitlist into
+// bookings while seats are free. Entries asking for more seats than are free
+// keep their place and let smaller ones behind them through. The caller must
+// hold the event row lock.
+//
+// Seats of paid events cannot be handed out before they are paid for, so those
+// customers get a seat hold instead, confirmed with a payment at the event's
+// price at that time. A hold that lapses passes its seats down the queue.
+func promoteWaitlist(tx *sql.Tx, eventID int) error {
+	var capacity, filled, held int
+	var price int64
+	var startsAt, endsAt time.Time
+	var started bool
+	eventQuery := `SELECT capacity, filled, held, price, starts_at, ends_at, starts_at <= NOW() FROM events_schema.events WHERE event_id = $1`
+	err := tx.QueryRow(eventQuery, eventID).Scan(&capacity, &filled, &held, &price, &startsAt, &endsAt, &started)
+	if err != nil {
+		return fmt.Errorf("failed to get event details: %v", err)
+	}
+
+	// Seats freed once the event has started are not handed out again
+	if started {
+		return nil
+	}
+
+	// Entries of customers who have booked since queueing can never be
+	// promoted, as a customer books an event once
+	staleQuery := `
+		DELETE FROM events_schema.event_waitlist w
+		WHERE w.event_id = $1
+		  AND EXISTS (SELECT 1 FROM events_schema.userbooked_events b WHERE b.event_id = w.event_id AND b.cid = w.cid)`
+	if _, err := tx.Exec(staleQuery, eventID); err != nil {
+		return fmt.Errorf("failed to clean up event waitlist: %v", err)
+	}
+
+	// Held seats are spoken for until their hold is released
+	free := capacity - filled - held
+	if free <= 0 {
+		return nil
+	}
+
+	// Seats left per tier; empty for events without tiers
+	tierFree := make(map[int]int)
+	tierRows, err := tx.Query(`SELECT tier_id, capacity - filled - held FROM events_schema.ticket_tiers WHERE event_id = $1`, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket tiers: %v", err)
+	}
+	for tierRows.Next() {
+		var tierID, left int
+		if err := tierRows.Scan(&tierID, &left); err != nil {
+			tierRows.Close()
+			return fmt.Errorf("failed to scan ticket tier: %v", err)
+		}
+		tierFree[tierID] = left
+	}
+	tierRows.Close()
+
+	queueQuery := `
+		SELECT waitlist_id, cid, cemail, cusername, tier_id, seats, attendee_names, answers
+		FROM events_schema.event_waitlist
+		WHERE event_id = $1
+		ORDER BY joined_at, waitlist_id`
+
+	rows, err := tx.Query(queueQuery, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event waitlist: %v", err)
+	}
+
+	type queued struct {
+		waitlistID int
+		cid        int
+		email      string
+		username   string
+		tierID     sql.NullInt64
+		seats      int
+		names      []string
+		answers    []byte
+	}
+	var queue []queued
+	for rows.Next() {
+		var q queued
+		if err := rows.Scan(&q.waitlistID, &q.cid, &q.email, &q.username, &q.tierID, &q.seats, pq.Array(&q.names), &q.answers); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan waitlist entry: %v", err)
+		}
+		queue = append(queue, q)
+	}
+	rows.Close()
+
+	for _, q := range queue {
+		if free == 0 {
+			break
+		}
+
+		if q.seats > free {
+			continue
+		}
+
+		// On tiered events the seats have to be free in the customer's tier
+		tierID := int(q.tierID.Int64)
+		if len(tierFree) > 0 && tierFree[tierID] < q.seats {
+			continue
+		}
+
+		// The customer's bookings must not change between the conflict check and
+		// the insert. Waiting for their lock while holding the event row could
+		// deadlock with their own join, so customers busy booking elsewhere keep
+		// their place and are skipped for these seats.
+		var locked bool
+		err := tx.QueryRow(`SELECT pg_try_advisory_xact_lock($1, $2)`, customerBookingLock, q.cid).Scan(&locked)
+		if err != nil {
+			return fmt.Errorf("failed to lock customer bookings: %v", err)
+		}
+		if !locked {
+			continue
+		}
+
+		// Customers who booked something overlapping since queueing keep their
+		// place but are skipped for these seats
+		var hasConflict bool
+		conflictQuery := `SELECT events_schema.check_customer_time_conflict($1, $2, $3, $4)`
+		err = tx.QueryRow(conflictQuery, q.cid, startsAt, endsAt, eventID).Scan(&hasConflict)
+		if err != nil {
+			return fmt.Errorf("failed to check time conflict: %v", err)
+		}
+		if hasConflict {
+			continue
+		}
+
+		if price > 0 {
+			holdQuery := `
+				INSERT INTO events_schema.seat_holds (event_id, cid, tier_id, seats, attendee_names, answers, expires_at)
+				VALUES ($1, $2, $3, $4, $5, $6, LEAST(NOW() + $7 * INTERVAL '1 second', $8))
+				ON CONFLICT (cid, event_id) DO NOTHING`
+			result, err := tx.Exec(holdQuery, eventID, q.cid, nullableID(tierID), q.seats, pq.Array(q.names), q.answers,
+				core.WaitlistHoldTTL.Seconds(), startsAt)
+			if err != nil {
+				return fmt.Errorf("failed to promote waitlisted customer: %v", err)
+			}
+			// Customers already holding seats of their own keep their place
+			if created, err := result.RowsAffected(); err != nil {
+				return fmt.Errorf("failed to promote waitlisted customer: %v", err)
+			} else if created == 0 {
+				continue
+			}
+		} else {
+			insertQuery := `
+				INSERT INTO events_schema.userbooked_events (event_id, cid, cemail, cusername, tier_id, seats, attendee_names, answers)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+			_, err = tx.Exec(insertQuery, eventID, q.cid, q.email, q.username, nullableID(tierID), q.seats, pq.Array(q.names), q.answers)
+			if err != nil {
+				return fmt.Errorf("failed to promote waitlisted customer: %v", err)
+			}
+		}
+
+		deleteQuery := `DELETE FROM events_schema.event_waitlist WHERE waitlist_id = $1`
+		if _, err := tx.Exec(deleteQuery, q.waitlistID); err != nil {
+			return fmt.Errorf("failed to promote waitlisted customer: %v", err)
+		}
+		free -= q.seats
+		if len(tierFree) > 0 {
+			tierFree[tierID] -= q.seats
+		}
+	}
+
+	return nil
+}
@@ -17,6 +17,13 @@ type Config struct {
 	APP_ENV    string `mapstructure:"APP_ENV"`
 	APP_PORT   string `mapstructure:"APP_PORT"`
 	JWT_SECRET string `mapstructure:"JWT_SECRET"`
+	// TICKET_SECRET signs ticket codes and must be at least 16 characters; changing
+	// it invalidates every issued ticket. Without it tickets are disabled.
+	TICKET_SECRET string `mapstructure:"TICKET_SECRET"`
+	// SEAT_HOLD_TTL is how long seats stay held during checkout, e.g. "10m"
+	SEAT_HOLD_TTL string `mapstructure:"SEAT_HOLD_TTL"`
+	// PAYMENT_PROVIDER selects who charges for paid events; only "fake" (the default) exists so far
+	PAYMENT_PROVIDER string `mapstructure:"PAYMENT_PROVIDER"`
 }
 
 func Loadconfig() (*Config, error) {
@@ -0,0 +1,87 @@
+package core
+
+import "time"
+
+// Who cancelled booked seats
+const (
+	CancelledByCustomer  = "customer"
+	CancelledByOrganizer = "organizer"
+)
+
+// Analytics caching; only results covering many bookings are worth caching
+const (
+	AnalyticsCacheTTL         = 5 * time.Minute
+	AnalyticsCacheMinBookings = 1000
+)
+
+// AnalyticsFilters selects the events analytics are computed over. From and To
+// bound the events' start times; bookings are bucketed by day in TimeZone.
+type AnalyticsFilters struct {
+	EventID  int        // 0 for all events the organizer works on
+	From     *time.Time // Inclusive
+	To       *time.Time // Exclusive
+	TimeZone string     // IANA name, defaults to UTC
+}
+
+// AnalyticsTotals are booking figures of one event or summed over several.
+// Rates are between 0 and 1; check-in and no-show rates only cover events that
+// have ended, so they are omitted until one has.
+type AnalyticsTotals struct {
+	Events         int      `json:"events,omitempty"`
+	Capacity       int      `json:"capacity"`
+	Filled         int      `json:"filled"`
+	FillRate       float64  `json:"fill_rate"`
+	Bookings       int      `json:"bookings"`
+	BookedSeats    int      `json:"booked_seats"`
+	Cancellations  int      `json:"cancellations"`
+	CancelledSeats int      `json:"cancelled_seats"`
+	CheckedIn      int      `json:"checked_in"` // Bookings of ended events checked in at the door
+	NoShows        int      `json:"no_shows"`   // Confirmed bookings of ended events never checked in
+	CheckInRate    *float64 `json:"check_in_rate,omitempty"`
+	NoShowRate     *float64 `json:"no_show_rate,omitempty"`
+}
+
+// EventAnalytics are the figures of one event
+type EventAnalytics struct {
+	EventID   int       `json:"event_id"`
+	EventName string    `json:"event_name"`
+	Status    string    `json:"status"`
+	StartsAt  time.Time `json:"starts_at"`
+	Ended     bool      `json:"ended"`
+	AnalyticsTotals
+}
+
+// DailyBookings counts the bookings made and seats cancelled on one day
+type DailyBookings struct {
+	Date           string `json:"date"` // Format: YYYY-MM-DD
+	Bookings       int    `json:"bookings"`
+	BookedSeats    int    `json:"booked_seats"`
+	Cancellations  int    `json:"cancellations"`
+	CancelledSeats int    `json:"cancelled_seats"`
+}
+
+// HourlyBookings counts the bookings made in one hour of the day (0-23)
+type HourlyBookings struct {
+	Hour     int `json:"hour"`
+	Bookings int `json:"bookings"`
+}
+
+// Analytics is an organizer's booking report over a set of events
+type Analytics struct {
+	From        *time.Time       `json:"from,omitempty"`
+	To          *time.Time       `json:"to,omitempty"`
+	TimeZone    string           `json:"time_zone"`
+	GeneratedAt time.Time        `json:"generated_at"`
+	Cached      bool             `json:"cached"`
+	Totals      AnalyticsTotals  `json:"totals"`
+	Events      []EventAnalytics `json:"events"`
+	Daily       []DailyBookings  `json:"daily"`
+	Hourly      []HourlyBookings `json:"hourly"`               // Every hour of the day, in TimeZone
+	PeakHours   []int            `json:"peak_hours,omitempty"` // Hours with the most bookings
+}
+
+// Cache keeps computed results for a while. Misses are not errors.
+type Cache interface {
+	Get(key string) ([]byte, bool, error)
+	Set(key string, value []byte, ttl time.Duration) error
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// Cancellation modes, applied to customers leaving after an event's cutoff
+const (
+	CancellationModeRefuse = "refuse" // Late cancellations are refused
+	CancellationModeFlag   = "flag"   // Late cancellations go through, flagged and charged the late penalty
+)
+
+// MaxCancellationCutoff bounds how long before the start cancellations may close
+const MaxCancellationCutoff = 30 * 24 * 60 // Minutes
+
+// CancellationPolicy sets until when customers may leave an event. Leaving is
+// never possible once the event has started.
+type CancellationPolicy struct {
+	CutoffMinutes int    `json:"cutoff_minutes"`         // Before the start; 0 allows leaving until the event starts
+	Mode          string `json:"mode"`                   // refuse (default) or flag
+	LatePenalty   int64  `json:"late_penalty,omitempty"` // Per seat released late in flag mode, in the event's currency minor unit
+}
+
+// Normalize defaults the mode and checks the policy's bounds
+func (p *CancellationPolicy) Normalize() error {
+	if p.Mode == "" {
+		p.Mode = CancellationModeRefuse
+	}
+	if p.Mode != CancellationModeRefuse && p.Mode != CancellationModeFlag {
+		return fmt.Errorf("invalid cancellation mode '%s'. Use %s or %s", p.Mode, CancellationModeRefuse, CancellationModeFlag)
+	}
+	if p.CutoffMinutes < 0 || p.CutoffMinutes > MaxCancellationCutoff {
+		return fmt.Errorf("cancellation cutoff must be between 0 and %d minutes", MaxCancellationCutoff)
+	}
+	if p.LatePenalty < 0 {
+		return fmt.Errorf("late cancellation penalty cannot be negative")
+	}
+	if p.LatePenalty > 0 && p.Mode != CancellationModeFlag {
+		return fmt.Errorf("late cancellation penalties need the %s mode", CancellationModeFlag)
+	}
+	if p.LatePenalty > 0 && p.CutoffMinutes == 0 {
+		return fmt.Errorf("late cancellation penalties need a cutoff")
+	}
+	return nil
+}
+
+// CheckLeave reports whether leaving an event starting at startsAt is late at
+// now, or why it is not allowed at all
+func (p CancellationPolicy) CheckLeave(startsAt time.Time, now time.Time) (bool, error) {
+	if !now.Before(startsAt) {
+		return false, fmt.Errorf("event has already started")
+	}
+	if p.CutoffMinutes == 0 {
+		return false, nil
+	}
+	deadline := startsAt.Add(-time.Duration(p.CutoffMinutes) * time.Minute)
+	if now.Before(deadline) {
+		return false, nil
+	}
+	if p.Mode == CancellationModeRefuse {
+		return false, fmt.Errorf("cancellations closed %s before the event starts", formatCutoff(p.CutoffMinutes))
+	}
+	return true, nil
+}
+
+// formatCutoff renders a cutoff as whole hours where it can, e.g. "24h"
+func formatCutoff(minutes int) string {
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
+
+// LateCancellation records seats a customer released after an event's cutoff
+type LateCancellation struct {
+	CancellationID int       `json:"cancellation_id"`
+	EventID        int       `json:"event_id"`
+	EventName      string    `json:"event_name,omitempty"`
+	CID            int       `json:"cid"`
+	CUsername      string    `json:"cusername,omitempty"`
+	Seats          int       `json:"seats"`
+	Penalty        int64     `json:"penalty"` // Minor units; withheld from the refund of paid seats
+	Currency       string    `json:"currency"`
+	CancelledAt    time.Time `json:"cancelled_at"`
+}
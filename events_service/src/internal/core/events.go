@@ -1,96 +1,379 @@
 package core
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrEventFull is returned by JoinEvent when the event has no seats left
+var ErrEventFull = errors.New("event is sold out")
+
+// VenueUnavailableError is returned when an event would overlap another live
+// event at the same venue
+type VenueUnavailableError struct {
+	VenueID            int `json:"venue_id"`
+	ConflictingEventID int `json:"conflicting_event_id,omitempty"` // 0 when the conflict was gone by the time it was looked up
+}
+
+func (e *VenueUnavailableError) Error() string {
+	if e.ConflictingEventID == 0 {
+		return "venue is not available for the given time slot"
+	}
+	return fmt.Sprintf("venue is not available for the given time slot, it conflicts with event %d", e.ConflictingEventID)
+}
+
+// Event lifecycle statuses
+const (
+	EventStatusDraft     = "draft"
+	EventStatusPublished = "published"
+	EventStatusCancelled = "cancelled"
+	EventStatusCompleted = "completed"
+)
+
+// Booking statuses
+const (
+	BookingStatusConfirmed            = "confirmed"
+	BookingStatusCancelledByOrganizer = "cancelled_by_organizer"
+)
+
+// Group booking limits
+const (
+	DefaultMaxSeatsPerBooking = 1   // Events allow one seat per booking unless the organizer raises it
+	MaxAttendeeNameLength     = 100 // Characters per attendee name
+	MaxBookingAnswers         = 20  // Custom answers per booking
+	MaxAnswerQuestionLength   = 100 // Characters per answer key
+	MaxAnswerLength           = 500 // Characters per answer
+)
+
+// eventStatusTransitions lists the statuses each status may move to
+var eventStatusTransitions = map[string][]string{
+	EventStatusDraft:     {EventStatusPublished},
+	EventStatusPublished: {EventStatusCancelled, EventStatusCompleted},
+}
+
+// IsValidEventStatus reports whether status is a known event status
+func IsValidEventStatus(status string) bool {
+	switch status {
+	case EventStatusDraft, EventStatusPublished, EventStatusCancelled, EventStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// CanTransitionEventStatus reports whether an event may move from one status to another
+func CanTransitionEventStatus(from, to string) bool {
+	for _, allowed := range eventStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
 
 // Event represents an event in the system
 type Event struct {
-	EventID      int       `json:"event_id"`
-	EventName    string    `json:"event_name"`
-	OrganizerID  int       `json:"organizer_id"`
-	Place        string    `json:"place"`
-	EventDate    time.Time `json:"-"`          // Internal use only
-	EventDateStr string    `json:"event_date"` // For JSON output: YYYY-MM-DD
-	StartTime    string    `json:"start_time"` // Format: HH:MM
-	EndTime      string    `json:"end_time"`   // Format: HH:MM
-	Capacity     int       `json:"capacity"`
-	Filled       int       `json:"filled"`
-	SeatsLeft    int       `json:"seats_left"` // Calculated field: capacity - filled
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
-}
-
-// CreateEventRequest represents the request to create an event
+	EventID            int                `json:"event_id"`
+	EventName          string             `json:"event_name"`
+	Description        string             `json:"description"`
+	OrganizerID        int                `json:"organizer_id"`
+	VenueID            int                `json:"venue_id"`
+	Place              string             `json:"place"` // Name of the venue
+	StartsAt           time.Time          `json:"starts_at"`
+	EndsAt             time.Time          `json:"ends_at"`
+	EventDateStr       string             `json:"event_date"`                  // Date of StartsAt: YYYY-MM-DD
+	EndDate            string             `json:"end_date"`                    // Date of EndsAt: YYYY-MM-DD
+	StartTime          string             `json:"start_time"`                  // Format: HH:MM
+	EndTime            string             `json:"end_time"`                    // Format: HH:MM
+	TimeZone           string             `json:"time_zone"`                   // IANA name the event is scheduled in
+	DisplayTimeZone    string             `json:"display_time_zone,omitempty"` // Set when times are converted for the client
+	Capacity           int                `json:"capacity"`
+	Filled             int                `json:"filled"`
+	Held               int                `json:"held"`       // Seats reserved by active holds
+	SeatsLeft          int                `json:"seats_left"` // Calculated field: capacity - filled - held
+	MaxSeatsPerBooking int                `json:"max_seats_per_booking"`
+	Price              int64              `json:"price"` // Per seat in the currency's minor unit; 0 is free
+	Currency           string             `json:"currency"`
+	CancellationPolicy CancellationPolicy `json:"cancellation_policy"`
+	Status             string             `json:"status"`
+	SeriesID           int                `json:"series_id,omitempty"` // Set for occurrences of a recurring series
+	Sequence           int                `json:"sequence"`            // Revision, bumped on every update
+	Tiers              []TicketTier       `json:"tiers,omitempty"`
+	BookingStatus      string             `json:"booking_status,omitempty"` // Only set when listing a customer's bookings
+	BookedSeats        int                `json:"booked_seats,omitempty"`   // Only set when listing a customer's bookings
+	Payment            *BookingPayment    `json:"payment,omitempty"`        // Only set when listing a customer's paid bookings
+	TeamRole           string             `json:"team_role,omitempty"`      // Only set when listing an organizer's events
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+}
+
+// CreateEventRequest represents the request to create an event. The time
+// range is given either as starts_at/ends_at or as event_date with start_time
+// and end_time (plus end_date for events spanning several days).
 type CreateEventRequest struct {
-	EventName   string `json:"event_name" validate:"required"`
-	OrganizerID int    `json:"organizer_id"` // This will be set from session
-	Place       string `json:"place" validate:"required"`
-	EventDate   string `json:"event_date" validate:"required"` // Format: YYYY-MM-DD
-	StartTime   string `json:"start_time" validate:"required"` // Format: HH:MM
-	EndTime     string `json:"end_time" validate:"required"`   // Format: HH:MM
-	Capacity    int    `json:"capacity" validate:"required,min=1"`
+	EventName          string              `json:"event_name" validate:"required"`
+	Description        string              `json:"description,omitempty"`
+	OrganizerID        int                 `json:"organizer_id"` // This will be set from session
+	VenueID            int                 `json:"venue_id,omitempty"`
+	Place              string              `json:"place,omitempty"`      // Venue name, used when venue_id is not given
+	StartsAt           string              `json:"starts_at,omitempty"`  // Format: RFC 3339
+	EndsAt             string              `json:"ends_at,omitempty"`    // Format: RFC 3339
+	EventDate          string              `json:"event_date,omitempty"` // Format: YYYY-MM-DD
+	EndDate            string              `json:"end_date,omitempty"`   // Format: YYYY-MM-DD, defaults to event_date
+	StartTime          string              `json:"start_time,omitempty"` // Format: HH:MM
+	EndTime            string              `json:"end_time,omitempty"`   // Format: HH:MM, at or before start_time runs overnight
+	TimeZone           string              `json:"time_zone,omitempty"`  // IANA name, defaults to UTC; date/time fields are read in it
+	Capacity           int                 `json:"capacity" validate:"required,min=1"`
+	MaxSeatsPerBooking int                 `json:"max_seats_per_booking,omitempty"` // Defaults to 1
+	Price              int64               `json:"price,omitempty"`                 // Per seat in minor units (cents); defaults to free
+	Currency           string              `json:"currency,omitempty"`              // ISO 4217 code, defaults to USD
+	CancellationPolicy *CancellationPolicy `json:"cancellation_policy,omitempty"`   // Defaults to leaving until the event starts
+	Status             string              `json:"status,omitempty"`                // draft or published (default)
+	Tiers              []TicketTierRequest `json:"tiers,omitempty"`                 // Optional; capacity defaults to their sum
 }
 
 // EventResponse represents the response for customers viewing events
 type EventResponse struct {
-	EventID       int    `json:"id"`
-	EventName     string `json:"name"`
-	OrganizerID   int    `json:"organizer"`
-	OrganizerName string `json:"organizer_name"`
-	Place         string `json:"place"`
-	EventDate     string `json:"date"`       // Format: YYYY-MM-DD
-	StartTime     string `json:"start_time"` // Format: HH:MM
-	EndTime       string `json:"end_time"`   // Format: HH:MM
-	Capacity      int    `json:"capacity"`
-	SeatsLeft     int    `json:"seats_left"`
+	EventID            int                `json:"id"`
+	EventName          string             `json:"name"`
+	Description        string             `json:"description"`
+	OrganizerID        int                `json:"organizer"`
+	OrganizerName      string             `json:"organizer_name"`
+	VenueID            int                `json:"venue_id"`
+	Place              string             `json:"place"`
+	StartsAt           time.Time          `json:"starts_at"`
+	EndsAt             time.Time          `json:"ends_at"`
+	EventDate          string             `json:"date"`       // Format: YYYY-MM-DD
+	EndDate            string             `json:"end_date"`   // Format: YYYY-MM-DD
+	StartTime          string             `json:"start_time"` // Format: HH:MM
+	EndTime            string             `json:"end_time"`   // Format: HH:MM
+	TimeZone           string             `json:"time_zone"`
+	DisplayTimeZone    string             `json:"display_time_zone,omitempty"` // Set when times are converted for the client
+	Capacity           int                `json:"capacity"`
+	SeatsLeft          int                `json:"seats_left"`
+	MaxSeatsPerBooking int                `json:"max_seats_per_booking"`
+	Price              int64              `json:"price"` // Per seat in the currency's minor unit; 0 is free
+	Currency           string             `json:"currency"`
+	CancellationPolicy CancellationPolicy `json:"cancellation_policy"`
+	Status             string             `json:"status"`
+	Tiers              []TicketTier       `json:"tiers,omitempty"`       // Per-tier seats_left
+	Snippet            string             `json:"snippet,omitempty"`     // Search matches wrapped in <mark>, set when searching
+	DistanceKm         *float64           `json:"distance_km,omitempty"` // Distance of the venue, set when searching near a point
 }
 
 // EventFilters represents filters for event listing
 type EventFilters struct {
-	Date        string `json:"date,omitempty"` // YYYY-MM-DD
-	Place       string `json:"place,omitempty"`
+	Query       string `json:"q,omitempty"`         // Full-text search over name, place and description
+	Date        string `json:"date,omitempty"`      // YYYY-MM-DD, matches events running on that day
+	TimeZone    string `json:"time_zone,omitempty"` // Zone Date is read in; defaults to each event's own zone
+	Place       string `json:"place,omitempty"`     // Matches venue names
+	VenueID     int    `json:"venue_id,omitempty"`
 	OrganizerID int    `json:"organizer,omitempty"`
+	Status      string `json:"status,omitempty"`   // Drafts are never listed
+	From        string `json:"from,omitempty"`     // YYYY-MM-DD, events running on or after that day
+	To          string `json:"to,omitempty"`       // YYYY-MM-DD, events running on or before that day
+	Upcoming    bool   `json:"upcoming,omitempty"` // Events that have not ended
+	Past        bool   `json:"past,omitempty"`     // Events that have ended
+	HasSeats    bool   `json:"has_seats,omitempty"`
+	MinCapacity int    `json:"min_capacity,omitempty"`
+	MaxCapacity int    `json:"max_capacity,omitempty"`
+	Sort        string `json:"sort,omitempty"`  // One of the EventSort keys
+	Order       string `json:"order,omitempty"` // asc or desc
+	Limit       int    `json:"limit,omitempty"`
+	Cursor      string `json:"cursor,omitempty"` // next_cursor of the previous page
+
+	Near     *GeoPoint `json:"near,omitempty"`      // Only events at venues with a known position
+	RadiusKm float64   `json:"radius_km,omitempty"` // Requires Near; 0 means no limit
+
+	After *EventCursor `json:"-"` // Decoded Cursor, set by validation
 }
 
 // JoinEventRequest represents the request to join an event by event ID
 type JoinEventRequest struct {
-	EventID int `json:"event_id" validate:"required"`
+	EventID       int               `json:"event_id" validate:"required"`
+	TierID        int               `json:"tier_id,omitempty"`        // Required when the event has ticket tiers
+	Seats         int               `json:"seats,omitempty"`          // Defaults to 1, at most the event's max_seats_per_booking
+	AttendeeNames []string          `json:"attendee_names,omitempty"` // Optional, at most one per seat
+	Answers       map[string]string `json:"answers,omitempty"`        // Optional custom answers keyed by question, e.g. "t_shirt_size"
+	PaymentMethod string            `json:"payment_method,omitempty"` // Payment provider token, required for paid events
+	PromoCode     string            `json:"promo_code,omitempty"`     // Optional discount code for paid events
+}
+
+// Validate checks the seats, attendee names and answers of a join request and
+// defaults the seats to one
+func (r *JoinEventRequest) Validate() error {
+	if r.Seats == 0 {
+		r.Seats = 1
+	}
+	if r.Seats < 0 {
+		return fmt.Errorf("seats must be greater than 0")
+	}
+
+	names := make([]string, 0, len(r.AttendeeNames))
+	for _, name := range r.AttendeeNames {
+		name = strings.Join(strings.Fields(name), " ")
+		if name == "" {
+			continue
+		}
+		if len([]rune(name)) > MaxAttendeeNameLength {
+			return fmt.Errorf("attendee names must be at most %d characters", MaxAttendeeNameLength)
+		}
+		names = append(names, name)
+	}
+	if len(names) > r.Seats {
+		return fmt.Errorf("got %d attendee names for %d seats", len(names), r.Seats)
+	}
+	r.AttendeeNames = names
+
+	if len(r.Answers) > MaxBookingAnswers {
+		return fmt.Errorf("at most %d answers can be given", MaxBookingAnswers)
+	}
+	answers := make(map[string]string, len(r.Answers))
+	for question, answer := range r.Answers {
+		question = strings.TrimSpace(question)
+		answer = strings.TrimSpace(answer)
+		if question == "" {
+			return fmt.Errorf("answer questions cannot be empty")
+		}
+		if len([]rune(question)) > MaxAnswerQuestionLength {
+			return fmt.Errorf("answer questions must be at most %d characters", MaxAnswerQuestionLength)
+		}
+		if len([]rune(answer)) > MaxAnswerLength {
+			return fmt.Errorf("answers must be at most %d characters", MaxAnswerLength)
+		}
+		if answer != "" {
+			answers[question] = answer
+		}
+	}
+	r.Answers = answers
+	return nil
 }
 
 // CustomerBooking represents a customer's booking information
 type CustomerBooking struct {
-	EventID   int       `json:"event_id"`
-	CID       int       `json:"cid"`
-	CEmail    string    `json:"cemail"`
-	CUsername string    `json:"cusername"`
-	BookedAt  time.Time `json:"booked_at"`
+	EventID       int               `json:"event_id"`
+	EventName     string            `json:"event_name,omitempty"` // Only set when exporting across events
+	CID           int               `json:"cid"`
+	CEmail        string            `json:"cemail"`
+	CUsername     string            `json:"cusername"`
+	Tier          string            `json:"tier,omitempty"`
+	Seats         int               `json:"seats"`
+	AttendeeNames []string          `json:"attendee_names,omitempty"`
+	Answers       map[string]string `json:"answers,omitempty"`
+	Status        string            `json:"status"`
+	BookedAt      time.Time         `json:"booked_at"`
+	CheckedIn     bool              `json:"checked_in"`
+	CheckedInAt   *time.Time        `json:"checked_in_at,omitempty"`
 }
 
 // JoinEventResponse represents the response when a customer joins an event
 type JoinEventResponse struct {
-	Message string `json:"message"`
-	EventID int    `json:"event_id"`
+	Message          string          `json:"message"`
+	EventID          int             `json:"event_id"`
+	Seats            int             `json:"seats"`
+	Waitlisted       bool            `json:"waitlisted"`
+	WaitlistPosition int             `json:"waitlist_position,omitempty"` // Set when Waitlisted is true
+	Ticket           *Ticket         `json:"ticket,omitempty"`            // Set once a seat is booked
+	Payment          *BookingPayment `json:"payment,omitempty"`           // Set when a paid event is booked
+}
+
+// LeaveEventResponse represents the response when a customer releases seats
+type LeaveEventResponse struct {
+	Message          string            `json:"message"`
+	EventID          int               `json:"event_id"`
+	Seats            int               `json:"seats"`                       // Seats still booked, 0 when the booking was cancelled
+	Refund           *Refund           `json:"refund,omitempty"`            // Set when paid seats were released
+	LateCancellation *LateCancellation `json:"late_cancellation,omitempty"` // Set when the seats were released after the cutoff
+}
+
+// WaitlistEntry represents a customer queued for a full event
+type WaitlistEntry struct {
+	EventID   int       `json:"event_id"`
+	EventName string    `json:"event_name,omitempty"`
+	CID       int       `json:"cid"`
+	CEmail    string    `json:"cemail"`
+	CUsername string    `json:"cusername"`
+	Seats     int       `json:"seats"`
+	Position  int       `json:"position"` // 1-based, 0 means promoted into a booking, or a seat hold on paid events
+	JoinedAt  time.Time `json:"joined_at"`
 }
 
 // UpdateEventRequest represents the request to update an event
 type UpdateEventRequest struct {
-	EventName string `json:"event_name,omitempty"`
-	Place     string `json:"place,omitempty"`
-	EventDate string `json:"event_date,omitempty"` // Format: YYYY-MM-DD
-	StartTime string `json:"start_time,omitempty"` // Format: HH:MM
-	EndTime   string `json:"end_time,omitempty"`   // Format: HH:MM
-	Capacity  int    `json:"capacity,omitempty"`
+	EventName          string              `json:"event_name,omitempty"`
+	Description        string              `json:"description,omitempty"`
+	VenueID            int                 `json:"venue_id,omitempty"`
+	Place              string              `json:"place,omitempty"`      // Venue name, used when venue_id is not given
+	StartsAt           string              `json:"starts_at,omitempty"`  // Format: RFC 3339
+	EndsAt             string              `json:"ends_at,omitempty"`    // Format: RFC 3339
+	EventDate          string              `json:"event_date,omitempty"` // Format: YYYY-MM-DD
+	EndDate            string              `json:"end_date,omitempty"`   // Format: YYYY-MM-DD
+	StartTime          string              `json:"start_time,omitempty"` // Format: HH:MM
+	EndTime            string              `json:"end_time,omitempty"`   // Format: HH:MM
+	TimeZone           string              `json:"time_zone,omitempty"`  // IANA name; date/time fields are read in it
+	Capacity           int                 `json:"capacity,omitempty"`
+	MaxSeatsPerBooking int                 `json:"max_seats_per_booking,omitempty"`
+	Price              *int64              `json:"price,omitempty"`               // Per seat in minor units; 0 makes the event free. Existing bookings keep their price.
+	Currency           string              `json:"currency,omitempty"`            // ISO 4217 code
+	CancellationPolicy *CancellationPolicy `json:"cancellation_policy,omitempty"` // Replaces the whole policy
+	Tiers              []TicketTierRequest `json:"tiers,omitempty"`               // Replaces tiers by name; omitted tiers are removed
 }
 
 // EventRepository defines the interface for event data operations
 type EventRepository interface {
 	CreateEvent(event *Event) (*Event, error)
 	GetEventByID(eventID int) (*Event, error)
-	GetAllEventsForCustomers(filters *EventFilters) ([]EventResponse, error)
-	JoinEvent(customerID int, eventID int) error
-	LeaveEvent(customerID int, eventID int) error
+	GetAllEventsForCustomers(filters *EventFilters) (*EventPage, error)
+	JoinEvent(customerID int, request *JoinEventRequest) error
+	LeaveEvent(customerID int, eventID int, seats int) (*LeaveEventResponse, error)
 	GetEventCustomers(eventID, organizerID int) ([]CustomerBooking, error)
-	GetOrganizerEvents(organizerID int) ([]Event, error)
+	GetOrganizerCustomers(organizerID int) ([]CustomerBooking, error)
+	GetOrganizerEvents(organizerID int, status string) ([]Event, error)
 	GetUserBookings(userID int) ([]Event, error)
 	UpdateEvent(eventID int, request *UpdateEventRequest, organizerID int) (*Event, error)
 	DeleteEvent(eventID int, organizerID int) error
+	UpdateEvents(eventIDs []int, request *UpdateEventRequest, organizerID int) ([]Event, error)
+	DeleteEvents(eventIDs []int, organizerID int) error
+	CreateSeries(series *EventSeries, occurrences []Event) (*SeriesResponse, error)
+	ImportEvents(events []Event, commit func(failed int) bool) ([]ImportedEvent, bool, error)
+	GetSeriesEventIDs(seriesID int, from time.Time) ([]int, error)
+	SetEventStatus(eventID int, from string, to string) error
+	CompletePastEvents() (int64, error)
+	JoinWaitlist(customerID int, request *JoinEventRequest) (*WaitlistEntry, error)
+	LeaveWaitlist(customerID int, eventID int) error
+	GetUserWaitlist(userID int) ([]WaitlistEntry, error)
+	GetEventWaitlist(eventID, organizerID int) ([]WaitlistEntry, error)
+	CreateSeatHold(customerID int, request *JoinEventRequest, ttl time.Duration) (*SeatHold, error)
+	GetUserSeatHolds(customerID int) ([]SeatHold, error)
+	GetSeatHold(customerID int, holdID int) (*SeatHold, error)
+	ConfirmSeatHold(customerID int, holdID int, payment *BookingPayment, redemption *PromoRedemption) (*SeatHold, error)
+	ReleaseSeatHold(customerID int, holdID int) error
+	ReleaseExpiredSeatHolds() (int64, error)
+	QueueRefund(refund *Refund) (*Refund, error)
+	GetPendingRefundIDs(limit int) ([]int, error)
+	ProcessRefund(refundID int, send func(refund *Refund) (*PaymentRefund, error)) (*Refund, error)
+	GetUserRefunds(customerID int) ([]Refund, error)
+	GetUserLateCancellations(customerID int) ([]LateCancellation, error)
+	GetEventLateCancellations(eventID int, organizerID int) ([]LateCancellation, error)
+	CreatePromoCode(promo *PromoCode) (*PromoCode, error)
+	GetOrganizerPromoCodes(organizerID int) ([]PromoCode, error)
+	DeactivatePromoCode(organizerID int, promoID int) error
+	GetPromoCodeForEvent(code string, eventID int, customerID int) (*PromoCode, int, error)
+	GetPromoRedemptions(organizerID int, promoID int) ([]PromoRedemption, error)
+	GetBookingTicket(customerID int, eventID int) (*Ticket, error)
+	CheckInBooking(bookingID int, eventID int, organizerID int) (*CheckIn, error)
+	GetCheckInManifest(eventID int, organizerID int) (*Manifest, error)
+	SyncCheckIns(eventID int, organizerID int, deviceID string, checkIns []OfflineCheckIn) (*CheckInSyncResponse, error)
+	GetOrganizerAnalytics(organizerID int, filters *AnalyticsFilters) (*Analytics, error)
+	GetEventRole(eventID int, organizerID int) (string, error)
+	GetEventTeam(eventID int) ([]TeamMember, error)
+	AddTeamMember(eventID int, request *AddTeamMemberRequest, addedBy int) (*TeamMember, error)
+	UpdateTeamMemberRole(eventID int, organizerID int, role string) error
+	RemoveTeamMember(eventID int, organizerID int) error
+	CreateCalendarFeed(userID int, kind string, tokenHash string) (*CalendarFeed, error)
+	RotateCalendarFeed(userID int, kind string, tokenHash string) (*CalendarFeed, error)
+	DeleteCalendarFeed(userID int, kind string) error
+	GetCalendarFeed(userID int, kind string) (*CalendarFeed, error)
+	GetCalendarFeedByToken(tokenHash string) (*CalendarFeed, error)
 }
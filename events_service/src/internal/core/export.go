@@ -0,0 +1,34 @@
+package core
+
+// Participant export columns. ExportColumnAnswers expands to one column per
+// question answered in the export; a single question is picked with
+// ExportColumnAnswerPrefix followed by the question, e.g. "answer:t_shirt_size".
+const (
+	ExportColumnEventID       = "event_id"
+	ExportColumnEventName     = "event_name"
+	ExportColumnName          = "name"
+	ExportColumnEmail         = "email"
+	ExportColumnTier          = "tier"
+	ExportColumnSeats         = "seats"
+	ExportColumnAttendeeNames = "attendee_names"
+	ExportColumnStatus        = "status"
+	ExportColumnBookedAt      = "booked_at"
+	ExportColumnCheckedIn     = "checked_in"
+	ExportColumnCheckedInAt   = "checked_in_at"
+	ExportColumnAnswers       = "answers"
+	ExportColumnAnswerPrefix  = "answer:"
+)
+
+// DefaultExportColumns are exported when no columns are requested; exports
+// across events start with the event ID and name
+var DefaultExportColumns = []string{
+	ExportColumnName, ExportColumnEmail, ExportColumnTier, ExportColumnSeats, ExportColumnStatus,
+	ExportColumnBookedAt, ExportColumnCheckedIn, ExportColumnAnswers,
+}
+
+// ParticipantExport is a table of participants ready to be written as a spreadsheet.
+// Row values are strings, ints or bools.
+type ParticipantExport struct {
+	Header []string
+	Rows   [][]interface{}
+}
@@ -0,0 +1,20 @@
+package core
+
+import "time"
+
+// Calendar feed kinds
+const (
+	CalendarFeedBookings  = "bookings"  // A customer's bookings
+	CalendarFeedOrganizer = "organizer" // An organizer's events
+)
+
+// CalendarFeed is a private iCalendar subscription URL. Only a hash of the
+// token is stored, so Token and URL are only set when it is created or rotated.
+type CalendarFeed struct {
+	UserID         int        `json:"-"`
+	Kind           string     `json:"kind"`
+	Token          string     `json:"token,omitempty"`
+	URL            string     `json:"url,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+}
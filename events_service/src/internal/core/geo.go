@@ -0,0 +1,97 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// EarthRadiusKm is the mean Earth radius used for distances
+const EarthRadiusKm = 6371.0
+
+// kmPerDegree is the length of one degree of latitude
+const kmPerDegree = math.Pi * EarthRadiusKm / 180
+
+// MaxRadiusKm bounds the radius of a near search, half the Earth's circumference
+const MaxRadiusKm = math.Pi * EarthRadiusKm
+
+// GeoPoint is a WGS 84 position in degrees
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// GeoBox is the latitude/longitude rectangle around a search circle. It only
+// narrows the candidates, the exact distance decides. WrapsLongitude is set when
+// the circle crosses the antimeridian or a pole, in which case the longitude
+// bounds must not be used.
+type GeoBox struct {
+	MinLatitude, MaxLatitude   float64
+	MinLongitude, MaxLongitude float64
+	WrapsLongitude             bool
+}
+
+// ParseGeoPoint parses "lat,lng" as given in the near query parameter
+func ParseGeoPoint(value string) (*GeoPoint, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid near '%s'. Use lat,lng", value)
+	}
+
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid near '%s'. Use lat,lng", value)
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid near '%s'. Use lat,lng", value)
+	}
+
+	point := &GeoPoint{Latitude: latitude, Longitude: longitude}
+	if err := ValidateCoordinates(&point.Latitude, &point.Longitude); err != nil {
+		return nil, err
+	}
+	return point, nil
+}
+
+// ValidateCoordinates checks an optional pair of coordinates: both or neither
+// must be set, within the valid degree ranges
+func ValidateCoordinates(latitude, longitude *float64) error {
+	if latitude == nil && longitude == nil {
+		return nil
+	}
+	if latitude == nil || longitude == nil {
+		return fmt.Errorf("latitude and longitude must be given together")
+	}
+	if math.IsNaN(*latitude) || *latitude < -90 || *latitude > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if math.IsNaN(*longitude) || *longitude < -180 || *longitude > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+// BoundingBox returns the rectangle holding every point within radiusKm of p
+func (p GeoPoint) BoundingBox(radiusKm float64) GeoBox {
+	latDelta := radiusKm / kmPerDegree
+	box := GeoBox{
+		MinLatitude: p.Latitude - latDelta,
+		MaxLatitude: p.Latitude + latDelta,
+	}
+	if box.MinLatitude <= -90 || box.MaxLatitude >= 90 {
+		box.WrapsLongitude = true
+		return box
+	}
+
+	// Degrees of longitude shrink towards the poles; use the widest latitude of the box
+	widest := math.Max(math.Abs(box.MinLatitude), math.Abs(box.MaxLatitude))
+	lngDelta := latDelta / math.Cos(widest*math.Pi/180)
+	box.MinLongitude = p.Longitude - lngDelta
+	box.MaxLongitude = p.Longitude + lngDelta
+	if box.MinLongitude < -180 || box.MaxLongitude > 180 {
+		box.WrapsLongitude = true
+	}
+	return box
+}
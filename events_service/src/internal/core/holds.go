@@ -0,0 +1,41 @@
+package core
+
+import "time"
+
+// Seat hold lifetimes. The TTL is configurable within these bounds.
+const (
+	DefaultSeatHoldTTL = 10 * time.Minute
+	MinSeatHoldTTL     = time.Minute
+	MaxSeatHoldTTL     = time.Hour
+)
+
+// WaitlistHoldTTL is how long customers promoted from the waitlist of a paid
+// event have to pay for their seats. Their holds never outlast the event's start.
+const WaitlistHoldTTL = 24 * time.Hour
+
+// SeatHoldSweepInterval is how often expired holds are released. Joins and new
+// holds release the expired holds of their event first, so this only bounds
+// how long seats_left may lag behind.
+const SeatHoldSweepInterval = 15 * time.Second
+
+// SeatHold reserves seats of an event for a customer during checkout. The
+// seats count against seats_left until the hold is confirmed into a booking,
+// released or expires.
+type SeatHold struct {
+	HoldID        int               `json:"hold_id"`
+	EventID       int               `json:"event_id"`
+	EventName     string            `json:"event_name"`
+	CID           int               `json:"-"`
+	TierID        int               `json:"tier_id,omitempty"`
+	Seats         int               `json:"seats"`
+	AttendeeNames []string          `json:"attendee_names,omitempty"`
+	Answers       map[string]string `json:"answers,omitempty"`
+	ExpiresAt     time.Time         `json:"expires_at"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// ConfirmHoldRequest represents the optional body of a hold confirmation
+type ConfirmHoldRequest struct {
+	PaymentMethod string `json:"payment_method,omitempty"` // Payment provider token, required for paid events
+	PromoCode     string `json:"promo_code,omitempty"`     // Optional discount code for paid events
+}
@@ -0,0 +1,56 @@
+package core
+
+// How an import treats rows that fail validation
+const (
+	ImportModeAllOrNothing = "all_or_nothing" // Any invalid row cancels the whole import (default)
+	ImportModeValidOnly    = "valid_only"     // Valid rows are created, invalid ones reported
+)
+
+// Import limits
+const (
+	MaxImportRows     = 500
+	MaxImportFileSize = 1 << 20 // Bytes
+)
+
+// Outcomes of an imported row
+const (
+	ImportRowCreated = "created" // The event was created
+	ImportRowValid   = "valid"   // The event would be created, but was not (dry run or cancelled import)
+	ImportRowInvalid = "invalid"
+)
+
+// ImportColumns are the CSV header names an event import accepts. They match
+// the JSON fields of CreateEventRequest; event_name and capacity are required.
+var ImportColumns = []string{
+	"event_name", "description", "venue_id", "place", "starts_at", "ends_at", "event_date", "end_date",
+	"start_time", "end_time", "time_zone", "capacity", "max_seats_per_booking", "status",
+}
+
+// ImportedEvent is the outcome of storing one imported event: its ID, or the
+// reason it could not be stored
+type ImportedEvent struct {
+	EventID int
+	Err     error
+}
+
+// ImportRowResult reports what became of one CSV row; Row is its line number
+type ImportRowResult struct {
+	Row                int    `json:"row"`
+	EventName          string `json:"event_name,omitempty"`
+	Status             string `json:"status"`
+	EventID            int    `json:"event_id,omitempty"`
+	Error              string `json:"error,omitempty"`
+	ConflictingEventID int    `json:"conflicting_event_id,omitempty"`
+}
+
+// ImportResponse represents the result of importing events from CSV
+type ImportResponse struct {
+	Mode      string            `json:"mode"`
+	DryRun    bool              `json:"dry_run"`
+	Committed bool              `json:"committed"` // Whether any events were created
+	Total     int               `json:"total"`
+	Valid     int               `json:"valid"`
+	Invalid   int               `json:"invalid"`
+	Created   int               `json:"created"`
+	Rows      []ImportRowResult `json:"rows"`
+}
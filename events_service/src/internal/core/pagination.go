@@ -0,0 +1,73 @@
+package core
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// Event listing sort keys
+const (
+	EventSortDate      = "date"       // Start time
+	EventSortName      = "name"       // Event name
+	EventSortCapacity  = "capacity"   // Total seats
+	EventSortSeatsLeft = "seats_left" // Free seats
+	EventSortRelevance = "relevance"  // Search rank, only with a search query
+	EventSortDistance  = "distance"   // Distance of the venue, only with a near point
+)
+
+// Sort orders
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// Page size bounds for event listings
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// EventPage is one page of an event listing. NextCursor is empty on the last page.
+type EventPage struct {
+	Events     []EventResponse
+	NextCursor string
+}
+
+// EventCursor marks the last event of a page. Value is the sort key of that
+// event in text form, ID breaks ties between equal keys.
+type EventCursor struct {
+	Sort  string `json:"s"`
+	Order string `json:"o"`
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+// IsValidEventSort reports whether sort is a known event sort key
+func IsValidEventSort(sort string) bool {
+	switch sort {
+	case EventSortDate, EventSortName, EventSortCapacity, EventSortSeatsLeft, EventSortRelevance, EventSortDistance:
+		return true
+	}
+	return false
+}
+
+// Encode returns the opaque form handed to clients as next_cursor
+func (c *EventCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeEventCursor parses a cursor returned by Encode
+func DecodeEventCursor(value string) (*EventCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	var cursor EventCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == 0 || !IsValidEventSort(cursor.Sort) {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &cursor, nil
+}
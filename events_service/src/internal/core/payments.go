@@ -0,0 +1,128 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultCurrency is used for events priced without a currency
+const DefaultCurrency = "USD"
+
+// MaxRefundAttempts is how often a refund is sent to the provider before it
+// is marked failed and left for manual follow-up
+const MaxRefundAttempts = 5
+
+// RefundRetryInterval is how often pending refunds are retried
+const RefundRetryInterval = time.Minute
+
+// Booking payment statuses
+const (
+	PaymentStatusNone              = "none" // Free booking
+	PaymentStatusPaid              = "paid"
+	PaymentStatusPartiallyRefunded = "partially_refunded"
+	PaymentStatusRefunded          = "refunded"
+)
+
+// Payment intent statuses reported by providers
+const (
+	PaymentIntentSucceeded = "succeeded"
+	PaymentIntentFailed    = "failed"
+)
+
+// Refund reasons and statuses
+const (
+	RefundReasonCustomerCancelled = "customer_cancelled"
+	RefundReasonEventCancelled    = "event_cancelled"
+
+	RefundStatusPending   = "pending"
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusFailed    = "failed"
+)
+
+// ErrPaymentRequired is returned when a paid event is booked without paying
+var ErrPaymentRequired = errors.New("this event requires payment")
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// NormalizeCurrency upper-cases an ISO 4217 code; empty means DefaultCurrency
+func NormalizeCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return DefaultCurrency, nil
+	}
+	if !currencyPattern.MatchString(currency) {
+		return "", fmt.Errorf("invalid currency '%s'. Use a three-letter ISO 4217 code such as USD", currency)
+	}
+	return currency, nil
+}
+
+// PaymentIntentRequest asks a provider to charge a customer. Requests with the
+// same IdempotencyKey charge at most once.
+type PaymentIntentRequest struct {
+	Amount         int64 // Minor units
+	Currency       string
+	CustomerID     int
+	EventID        int
+	PaymentMethod  string // Provider token chosen by the client
+	Description    string
+	IdempotencyKey string
+}
+
+// PaymentIntent is a provider's answer to a charge
+type PaymentIntent struct {
+	ID            string
+	Amount        int64
+	Currency      string
+	Status        string
+	FailureReason string // Set when Status is failed
+}
+
+// RefundRequest asks a provider to return part or all of a payment
+type RefundRequest struct {
+	PaymentID      string
+	Amount         int64 // Minor units
+	IdempotencyKey string
+}
+
+// PaymentRefund is a provider's answer to a refund
+type PaymentRefund struct {
+	ID     string
+	Amount int64
+}
+
+// PaymentProvider charges customers and refunds them. Implementations must
+// honour idempotency keys, as calls are retried after failures.
+type PaymentProvider interface {
+	CreatePaymentIntent(request *PaymentIntentRequest) (*PaymentIntent, error)
+	Refund(request *RefundRequest) (*PaymentRefund, error)
+}
+
+// BookingPayment is what was charged for a booking
+type BookingPayment struct {
+	PaymentID string `json:"payment_id"`
+	Status    string `json:"status"`
+	UnitPrice int64  `json:"unit_price"`
+	Amount    int64  `json:"amount"`
+	Refunded  int64  `json:"refunded"`
+	Currency  string `json:"currency"`
+}
+
+// Refund is a queued or processed refund of a booking's payment
+type Refund struct {
+	RefundID         int        `json:"refund_id"`
+	EventID          int        `json:"event_id"`
+	CID              int        `json:"-"`
+	PaymentID        string     `json:"payment_id"`
+	Amount           int64      `json:"amount"`
+	Currency         string     `json:"currency"`
+	Reason           string     `json:"reason"`
+	Status           string     `json:"status"`
+	ProviderRefundID string     `json:"provider_refund_id,omitempty"`
+	Attempts         int        `json:"attempts"`
+	LastError        string     `json:"last_error,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	ProcessedAt      *time.Time `json:"processed_at,omitempty"`
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Promo code kinds
+const (
+	PromoKindPercentage = "percentage" // Amount is the percent taken off
+	PromoKindFixed      = "fixed"      // Amount is taken off the booking, in minor units
+)
+
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// NormalizePromoCode upper-cases a code and checks its characters
+func NormalizePromoCode(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !promoCodePattern.MatchString(code) {
+		return "", fmt.Errorf("promo codes are 3 to 32 letters, digits, dashes or underscores")
+	}
+	return code, nil
+}
+
+// PromoCode is a discount an organizer offers on one event or, without an
+// event, on every event they created
+type PromoCode struct {
+	PromoID            int        `json:"promo_id"`
+	OrganizerID        int        `json:"organizer_id"`
+	EventID            int        `json:"event_id,omitempty"`
+	Code               string     `json:"code"`
+	Kind               string     `json:"kind"`
+	Amount             int64      `json:"amount"`
+	Currency           string     `json:"currency,omitempty"`              // Set for fixed discounts
+	MaxUses            int        `json:"max_uses,omitempty"`              // 0 is unlimited
+	MaxUsesPerCustomer int        `json:"max_uses_per_customer,omitempty"` // 0 is unlimited
+	Uses               int        `json:"uses"`
+	ValidFrom          *time.Time `json:"valid_from,omitempty"`
+	ValidUntil         *time.Time `json:"valid_until,omitempty"`
+	Active             bool       `json:"active"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// CheckUsable reports why the code cannot be used on event at now, if it cannot.
+// Usage limits are checked separately as they depend on the customer.
+func (p *PromoCode) CheckUsable(event *Event, now time.Time) error {
+	if !p.Active {
+		return fmt.Errorf("promo code is no longer active")
+	}
+	if p.ValidFrom != nil && now.Before(*p.ValidFrom) {
+		return fmt.Errorf("promo code is not valid yet")
+	}
+	if p.ValidUntil != nil && !now.Before(*p.ValidUntil) {
+		return fmt.Errorf("promo code has expired")
+	}
+	if p.EventID != 0 && p.EventID != event.EventID {
+		return fmt.Errorf("promo code is not valid for this event")
+	}
+	if p.EventID == 0 && p.OrganizerID != event.OrganizerID {
+		return fmt.Errorf("promo code is not valid for this event")
+	}
+	if p.Kind == PromoKindFixed && p.Currency != event.Currency {
+		return fmt.Errorf("promo code is not valid for prices in %s", event.Currency)
+	}
+	return nil
+}
+
+// CheckUses reports whether the code's usage limits are reached, given the
+// times the customer has already used it
+func (p *PromoCode) CheckUses(customerUses int) error {
+	if p.MaxUses > 0 && p.Uses >= p.MaxUses {
+		return fmt.Errorf("promo code has been used up")
+	}
+	if p.MaxUsesPerCustomer > 0 && customerUses >= p.MaxUsesPerCustomer {
+		return fmt.Errorf("you have already used this promo code")
+	}
+	return nil
+}
+
+// Discount is what the code takes off subtotal, never more than subtotal
+func (p *PromoCode) Discount(subtotal int64) int64 {
+	discount := p.Amount
+	if p.Kind == PromoKindPercentage {
+		discount = subtotal * p.Amount / 100
+	}
+	return min(discount, subtotal)
+}
+
+// CreatePromoCodeRequest represents the request to create a promo code
+type CreatePromoCodeRequest struct {
+	Code               string `json:"code"`
+	EventID            int    `json:"event_id,omitempty"` // Omit to cover every event the organizer created
+	Kind               string `json:"kind"`               // percentage or fixed
+	Amount             int64  `json:"amount"`             // Percent (1-100) or minor units
+	Currency           string `json:"currency,omitempty"` // Fixed discounts only, defaults to USD
+	MaxUses            int    `json:"max_uses,omitempty"`
+	MaxUsesPerCustomer int    `json:"max_uses_per_customer,omitempty"`
+	ValidFrom          string `json:"valid_from,omitempty"`  // Format: RFC 3339
+	ValidUntil         string `json:"valid_until,omitempty"` // Format: RFC 3339
+}
+
+// PromoQuote is the price of a booking with a promo code applied
+type PromoQuote struct {
+	Code     string `json:"code"`
+	EventID  int    `json:"event_id"`
+	Seats    int    `json:"seats"`
+	Subtotal int64  `json:"subtotal"`
+	Discount int64  `json:"discount"`
+	Total    int64  `json:"total"`
+	Currency string `json:"currency"`
+}
+
+// PromoRedemption records a promo code applied to a booking
+type PromoRedemption struct {
+	RedemptionID int       `json:"redemption_id"`
+	PromoID      int       `json:"promo_id"`
+	EventID      int       `json:"event_id"`
+	EventName    string    `json:"event_name,omitempty"`
+	CID          int       `json:"cid"`
+	CUsername    string    `json:"cusername,omitempty"`
+	Seats        int       `json:"seats"`
+	Subtotal     int64     `json:"subtotal"`
+	Discount     int64     `json:"discount"`
+	AmountPaid   int64     `json:"amount_paid"`
+	Currency     string    `json:"currency"`
+	PaymentID    string    `json:"payment_id,omitempty"`
+	RedeemedAt   time.Time `json:"redeemed_at"`
+}
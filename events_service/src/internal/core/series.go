@@ -0,0 +1,41 @@
+package core
+
+import "time"
+
+// Scopes for editing an occurrence of a recurring series
+const (
+	SeriesScopeThis      = "this"      // Only the given occurrence
+	SeriesScopeFollowing = "following" // The given occurrence and every later one
+	SeriesScopeAll       = "all"       // Every occurrence of the series
+)
+
+// EventSeries represents a recurring series whose occurrences are stored as events
+type EventSeries struct {
+	SeriesID    int         `json:"series_id"`
+	OrganizerID int         `json:"organizer_id"`
+	RRule       string      `json:"rrule"`
+	ExDates     []time.Time `json:"-"`
+}
+
+// CreateSeriesRequest represents the request to create a recurring series.
+// The event fields describe every occurrence; the first one starts at the given start.
+type CreateSeriesRequest struct {
+	CreateEventRequest
+	RRule   string   `json:"rrule" validate:"required"` // RFC 5545 subset, e.g. FREQ=WEEKLY;BYDAY=TU;COUNT=8
+	ExDates []string `json:"exdates,omitempty"`         // Skipped dates, format: YYYY-MM-DD
+}
+
+// SeriesCollision reports an occurrence that could not be created
+type SeriesCollision struct {
+	Date               string `json:"date"` // Format: YYYY-MM-DD
+	Reason             string `json:"reason"`
+	ConflictingEventID int    `json:"conflicting_event_id,omitempty"`
+}
+
+// SeriesResponse represents the result of creating a recurring series
+type SeriesResponse struct {
+	SeriesID   int               `json:"series_id"`
+	RRule      string            `json:"rrule"`
+	Events     []Event           `json:"events"`
+	Collisions []SeriesCollision `json:"collisions,omitempty"`
+}
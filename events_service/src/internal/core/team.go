@@ -0,0 +1,77 @@
+package core
+
+import (
+	"slices"
+	"time"
+)
+
+// Event team roles. The organizer who created an event is always one of its owners.
+const (
+	TeamRoleOwner   = "owner"    // Everything, including deleting the event and managing the team
+	TeamRoleEditor  = "editor"   // Edit, publish and cancel the event, check in and view attendees
+	TeamRoleCheckIn = "check_in" // Check in and view attendees
+	TeamRoleViewer  = "viewer"   // View attendees, waitlist and exports
+)
+
+// Event permissions, granted to team members by role
+const (
+	PermissionView    = "view"
+	PermissionCheckIn = "check_in"
+	PermissionEdit    = "edit"
+	PermissionManage  = "manage"
+)
+
+// teamRolePermissions lists the permissions each role grants
+var teamRolePermissions = map[string][]string{
+	TeamRoleOwner:   {PermissionView, PermissionCheckIn, PermissionEdit, PermissionManage},
+	TeamRoleEditor:  {PermissionView, PermissionCheckIn, PermissionEdit},
+	TeamRoleCheckIn: {PermissionView, PermissionCheckIn},
+	TeamRoleViewer:  {PermissionView},
+}
+
+// IsValidTeamRole reports whether role is a known team role
+func IsValidTeamRole(role string) bool {
+	_, ok := teamRolePermissions[role]
+	return ok
+}
+
+// RoleHasPermission reports whether a team role grants permission
+func RoleHasPermission(role string, permission string) bool {
+	return slices.Contains(teamRolePermissions[role], permission)
+}
+
+// RolesWithPermission lists the team roles that grant permission
+func RolesWithPermission(permission string) []string {
+	var roles []string
+	for _, role := range []string{TeamRoleOwner, TeamRoleEditor, TeamRoleCheckIn, TeamRoleViewer} {
+		if RoleHasPermission(role, permission) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// TeamMember is an organizer on an event's team
+type TeamMember struct {
+	EventID     int       `json:"event_id"`
+	OrganizerID int       `json:"organizer_id"`
+	Username    string    `json:"username"`
+	Email       string    `json:"email"`
+	Role        string    `json:"role"`
+	Creator     bool      `json:"creator"` // The organizer who created the event; cannot be removed or demoted
+	AddedBy     int       `json:"added_by"`
+	AddedAt     time.Time `json:"added_at"`
+}
+
+// AddTeamMemberRequest represents the request to add an organizer to an event's
+// team, identified by organizer ID or email
+type AddTeamMemberRequest struct {
+	OrganizerID int    `json:"organizer_id,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Role        string `json:"role"`
+}
+
+// UpdateTeamMemberRequest represents the request to change a team member's role
+type UpdateTeamMemberRequest struct {
+	Role string `json:"role"`
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTicketsDisabled is returned by ticket operations when the service runs
+// without a ticket secret
+var ErrTicketsDisabled = errors.New("tickets are disabled on this server")
+
+// Ticket is the proof of a booking shown at the door. Code is signed and
+// encodes the booking and event, so it cannot be altered or made up.
+type Ticket struct {
+	BookingID     int        `json:"booking_id"`
+	EventID       int        `json:"event_id"`
+	EventName     string     `json:"event_name"`
+	Place         string     `json:"place"`
+	StartsAt      time.Time  `json:"starts_at"`
+	EndsAt        time.Time  `json:"ends_at"`
+	TimeZone      string     `json:"time_zone"`
+	Seats         int        `json:"seats"`
+	AttendeeNames []string   `json:"attendee_names,omitempty"`
+	Status        string     `json:"status"`         // Booking status
+	Code          string     `json:"code,omitempty"` // Empty while tickets are disabled
+	CheckedInAt   *time.Time `json:"checked_in_at,omitempty"`
+}
+
+// CheckInRequest represents the request to check in a scanned ticket
+type CheckInRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// CheckIn represents a booking admitted at the door; all its seats are checked in at once
+type CheckIn struct {
+	BookingID     int       `json:"booking_id"`
+	EventID       int       `json:"event_id"`
+	CID           int       `json:"cid"`
+	CUsername     string    `json:"cusername"`
+	Tier          string    `json:"tier,omitempty"`
+	Seats         int       `json:"seats"`
+	AttendeeNames []string  `json:"attendee_names,omitempty"`
+	CheckedInAt   time.Time `json:"checked_in_at"`
+}
+
+// TicketUsedError is returned when a ticket that was already checked in is scanned again
+type TicketUsedError struct {
+	BookingID   int       `json:"booking_id"`
+	CheckedInAt time.Time `json:"checked_in_at"`
+}
+
+func (e *TicketUsedError) Error() string {
+	return fmt.Sprintf("ticket was already used at %s", e.CheckedInAt.UTC().Format(time.RFC3339))
+}
+
+// Offline check-in limits
+const (
+	MaxCheckInSyncBatch = 1000            // Check-ins per sync request
+	MaxCheckInClockSkew = 5 * time.Minute // How far in the future a device clock may run
+	MaxDeviceIDLength   = 100
+)
+
+// Results of a synced offline check-in
+const (
+	CheckInSyncApplied   = "applied"   // Recorded, possibly replacing a later check-in
+	CheckInSyncDuplicate = "duplicate" // The booking was already checked in earlier; that time is kept
+	CheckInSyncRejected  = "rejected"  // Not a valid booking of the event
+)
+
+// ManifestEntry is one booking in a check-in manifest. TicketHash is the hex
+// SHA-256 of the ticket code; door apps hash scanned codes to look them up.
+type ManifestEntry struct {
+	BookingID     int        `json:"booking_id"`
+	CID           int        `json:"cid"`
+	CUsername     string     `json:"cusername"`
+	Tier          string     `json:"tier,omitempty"`
+	Seats         int        `json:"seats"`
+	AttendeeNames []string   `json:"attendee_names,omitempty"`
+	TicketHash    string     `json:"ticket_hash"`
+	CheckedInAt   *time.Time `json:"checked_in_at,omitempty"`
+}
+
+// Manifest lists the confirmed bookings of an event for checking in offline
+type Manifest struct {
+	EventID     int             `json:"event_id"`
+	EventName   string          `json:"event_name"`
+	Place       string          `json:"place"`
+	StartsAt    time.Time       `json:"starts_at"`
+	EndsAt      time.Time       `json:"ends_at"`
+	GeneratedAt time.Time       `json:"generated_at"`
+	Entries     []ManifestEntry `json:"entries"`
+}
+
+// SignedManifest carries a Manifest as the exact JSON bytes that were signed,
+// so door apps verify Signature over the decoded Payload before trusting it
+type SignedManifest struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`
+	Payload   string `json:"payload"`   // Base64 of the manifest JSON
+	Signature string `json:"signature"` // Base64 of the signature over the decoded payload
+}
+
+// ManifestKey is the public key check-in manifests are verified with
+type ManifestKey struct {
+	Algorithm string `json:"algorithm"`
+	KeyID     string `json:"key_id"`
+	PublicKey string `json:"public_key"` // Base64 of the raw key
+}
+
+// OfflineCheckIn is a check-in a door app recorded while offline
+type OfflineCheckIn struct {
+	BookingID   int       `json:"booking_id"`
+	CheckedInAt time.Time `json:"checked_in_at"`
+}
+
+// CheckInSyncRequest represents a batch of offline check-ins uploaded by one device
+type CheckInSyncRequest struct {
+	DeviceID string           `json:"device_id"`
+	CheckIns []OfflineCheckIn `json:"check_ins"`
+}
+
+// CheckInSyncResult reports what became of one uploaded check-in. When bookings
+// are checked in more than once the earliest time wins.
+type CheckInSyncResult struct {
+	BookingID   int        `json:"booking_id"`
+	Status      string     `json:"status"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"` // Time now on record
+	Device      string     `json:"device,omitempty"`        // Device the time on record came from; empty for online check-ins
+	ReplacedAt  *time.Time `json:"replaced_at,omitempty"`   // Later time this check-in replaced
+	Reason      string     `json:"reason,omitempty"`        // Set for rejected check-ins
+}
+
+// CheckInSyncResponse summarises a sync; conflicts are the duplicates plus the
+// applied check-ins that replaced a later one
+type CheckInSyncResponse struct {
+	Applied    int                 `json:"applied"`
+	Duplicates int                 `json:"duplicates"`
+	Rejected   int                 `json:"rejected"`
+	Conflicts  int                 `json:"conflicts"`
+	Results    []CheckInSyncResult `json:"results"`
+}
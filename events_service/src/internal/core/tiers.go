@@ -0,0 +1,94 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TicketTier represents a ticket type of an event with its own capacity and sale window
+type TicketTier struct {
+	TierID      int        `json:"tier_id"`
+	EventID     int        `json:"event_id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Capacity    int        `json:"capacity"`
+	Filled      int        `json:"filled"`
+	Held        int        `json:"held"`       // Seats reserved by active holds
+	SeatsLeft   int        `json:"seats_left"` // Calculated field: capacity - filled - held
+	SalesStart  *time.Time `json:"sales_start,omitempty"`
+	SalesEnd    *time.Time `json:"sales_end,omitempty"`
+}
+
+// TicketTierRequest represents a ticket tier on create and update requests
+type TicketTierRequest struct {
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description,omitempty"`
+	Capacity    int    `json:"capacity" validate:"required,min=1"`
+	SalesStart  string `json:"sales_start,omitempty"` // Format: RFC 3339
+	SalesEnd    string `json:"sales_end,omitempty"`   // Format: RFC 3339
+}
+
+// OnSale reports whether the tier's sale window contains the given instant
+func (t *TicketTier) OnSale(at time.Time) bool {
+	if t.SalesStart != nil && at.Before(*t.SalesStart) {
+		return false
+	}
+	if t.SalesEnd != nil && !at.Before(*t.SalesEnd) {
+		return false
+	}
+	return true
+}
+
+// ParseTicketTiers validates tier requests and returns the tiers with their total capacity
+func ParseTicketTiers(requests []TicketTierRequest) ([]TicketTier, int, error) {
+	tiers := make([]TicketTier, 0, len(requests))
+	seen := make(map[string]bool)
+	total := 0
+
+	for _, req := range requests {
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			return nil, 0, fmt.Errorf("ticket tier name is required")
+		}
+		key := strings.ToLower(name)
+		if seen[key] {
+			return nil, 0, fmt.Errorf("duplicate ticket tier '%s'", name)
+		}
+		seen[key] = true
+
+		if req.Capacity <= 0 {
+			return nil, 0, fmt.Errorf("capacity of ticket tier '%s' must be greater than 0", name)
+		}
+
+		tier := TicketTier{
+			Name:        name,
+			Description: req.Description,
+			Capacity:    req.Capacity,
+			SeatsLeft:   req.Capacity,
+		}
+
+		if req.SalesStart != "" {
+			salesStart, err := time.Parse(time.RFC3339, req.SalesStart)
+			if err != nil {
+				return nil, 0, fmt.Errorf("invalid sales_start for ticket tier '%s'. Use RFC 3339", name)
+			}
+			tier.SalesStart = &salesStart
+		}
+		if req.SalesEnd != "" {
+			salesEnd, err := time.Parse(time.RFC3339, req.SalesEnd)
+			if err != nil {
+				return nil, 0, fmt.Errorf("invalid sales_end for ticket tier '%s'. Use RFC 3339", name)
+			}
+			tier.SalesEnd = &salesEnd
+		}
+		if tier.SalesStart != nil && tier.SalesEnd != nil && !tier.SalesEnd.After(*tier.SalesStart) {
+			return nil, 0, fmt.Errorf("sales_end must be after sales_start for ticket tier '%s'", name)
+		}
+
+		total += req.Capacity
+		tiers = append(tiers, tier)
+	}
+
+	return tiers, total, nil
+}
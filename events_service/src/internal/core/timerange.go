@@ -0,0 +1,198 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Layouts of the date and time fields accepted alongside starts_at/ends_at
+const (
+	DateLayout = "2006-01-02"
+	TimeLayout = "15:04"
+)
+
+// DefaultTimeZone is used for events created without a time zone
+const DefaultTimeZone = "UTC"
+
+// zoneCache keeps loaded locations, time.LoadLocation reads the zone database every call
+var zoneCache sync.Map
+
+// LoadTimeZone resolves an IANA time zone name such as "Europe/Berlin". An
+// empty name is UTC; "Local" is rejected as it depends on the server.
+func LoadTimeZone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = DefaultTimeZone
+	}
+	if cached, ok := zoneCache.Load(name); ok {
+		return cached.(*time.Location), nil
+	}
+	if name == "Local" {
+		return nil, fmt.Errorf("invalid time zone '%s'. Use an IANA name such as Europe/Berlin", name)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone '%s'. Use an IANA name such as Europe/Berlin", name)
+	}
+	zoneCache.Store(name, loc)
+	return loc, nil
+}
+
+// ResolveTimeRange returns the instants an event runs between. The range
+// fields (RFC 3339) take precedence; otherwise the date/time fields are read
+// as wall-clock times in loc, where an end time at or before the start time
+// without an end date means the event runs overnight into the next day.
+func ResolveTimeRange(startsAt, endsAt, eventDate, endDate, startTime, endTime string, loc *time.Location) (time.Time, time.Time, error) {
+	if startsAt != "" || endsAt != "" {
+		if startsAt == "" || endsAt == "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("both starts_at and ends_at are required")
+		}
+		start, err := time.Parse(time.RFC3339, startsAt)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid starts_at format. Use RFC 3339")
+		}
+		end, err := time.Parse(time.RFC3339, endsAt)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid ends_at format. Use RFC 3339")
+		}
+		if !end.After(start) {
+			return time.Time{}, time.Time{}, fmt.Errorf("ends_at must be after starts_at")
+		}
+		return start.In(loc), end.In(loc), nil
+	}
+
+	date, err := time.Parse(DateLayout, eventDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+	}
+	startClock, err := time.Parse(TimeLayout, startTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start time format. Use HH:MM")
+	}
+	endClock, err := time.Parse(TimeLayout, endTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end time format. Use HH:MM")
+	}
+
+	lastDate := date
+	if endDate != "" {
+		lastDate, err = time.Parse(DateLayout, endDate)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid end date format. Use YYYY-MM-DD")
+		}
+	}
+
+	start := combineDateAndClock(date, startClock, loc)
+	end := combineDateAndClock(lastDate, endClock, loc)
+	if endDate == "" && !end.After(start) {
+		end = combineDateAndClock(lastDate.AddDate(0, 0, 1), endClock, loc)
+	}
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("event must end after it starts")
+	}
+
+	return start, end, nil
+}
+
+// ApplyTimeUpdate computes an event's new range from the time fields of a
+// partial update. Fields left empty keep their current wall-clock value in
+// the event's zone; moving the start date keeps the number of days the event
+// spans. Changing the zone alone keeps the wall-clock times, so the instants
+// move. It reports false when the update does not touch the time range.
+func (r *UpdateEventRequest) ApplyTimeUpdate(startsAt, endsAt time.Time, from, to *time.Location) (time.Time, time.Time, bool, error) {
+	if r.StartsAt == "" && r.EndsAt == "" && r.EventDate == "" && r.EndDate == "" &&
+		r.StartTime == "" && r.EndTime == "" && from.String() == to.String() {
+		return startsAt, endsAt, false, nil
+	}
+
+	start, end := startsAt.In(from), endsAt.In(from)
+	if r.StartsAt != "" || r.EndsAt != "" {
+		var err error
+		if r.StartsAt != "" {
+			if start, err = time.Parse(time.RFC3339, r.StartsAt); err != nil {
+				return start, end, false, fmt.Errorf("invalid starts_at format. Use RFC 3339")
+			}
+		}
+		if r.EndsAt != "" {
+			if end, err = time.Parse(time.RFC3339, r.EndsAt); err != nil {
+				return start, end, false, fmt.Errorf("invalid ends_at format. Use RFC 3339")
+			}
+		}
+		start, end = start.In(to), end.In(to)
+	} else {
+		startDate := dateOf(start)
+		span := int(dateOf(end).Sub(startDate).Hours()/24 + 0.5)
+		startClock, endClock := start, end
+
+		if r.EventDate != "" {
+			date, err := time.Parse(DateLayout, r.EventDate)
+			if err != nil {
+				return start, end, false, fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+			}
+			startDate = date
+		}
+		if r.StartTime != "" {
+			clock, err := time.Parse(TimeLayout, r.StartTime)
+			if err != nil {
+				return start, end, false, fmt.Errorf("invalid start time format. Use HH:MM")
+			}
+			startClock = clock
+		}
+		if r.EndTime != "" {
+			clock, err := time.Parse(TimeLayout, r.EndTime)
+			if err != nil {
+				return start, end, false, fmt.Errorf("invalid end time format. Use HH:MM")
+			}
+			endClock = clock
+		}
+
+		endDate := startDate.AddDate(0, 0, span)
+		if r.EndDate != "" {
+			date, err := time.Parse(DateLayout, r.EndDate)
+			if err != nil {
+				return start, end, false, fmt.Errorf("invalid end date format. Use YYYY-MM-DD")
+			}
+			endDate = date
+		}
+
+		start = combineDateAndClock(startDate, startClock, to)
+		end = combineDateAndClock(endDate, endClock, to)
+		// A same-day event whose new end time is before its start runs overnight
+		if r.EndDate == "" && span == 0 && !end.After(start) {
+			end = combineDateAndClock(endDate.AddDate(0, 0, 1), endClock, to)
+		}
+	}
+
+	if !end.After(start) {
+		return start, end, false, fmt.Errorf("event must end after it starts")
+	}
+
+	return start, end, true, nil
+}
+
+// Localize renders the event's range in loc, updating the date and time fields
+func (e *Event) Localize(loc *time.Location) {
+	e.StartsAt, e.EndsAt = e.StartsAt.In(loc), e.EndsAt.In(loc)
+	e.EventDateStr, e.EndDate = e.StartsAt.Format(DateLayout), e.EndsAt.Format(DateLayout)
+	e.StartTime, e.EndTime = e.StartsAt.Format(TimeLayout), e.EndsAt.Format(TimeLayout)
+}
+
+// Localize renders the event's range in loc, updating the date and time fields
+func (e *EventResponse) Localize(loc *time.Location) {
+	e.StartsAt, e.EndsAt = e.StartsAt.In(loc), e.EndsAt.In(loc)
+	e.EventDate, e.EndDate = e.StartsAt.Format(DateLayout), e.EndsAt.Format(DateLayout)
+	e.StartTime, e.EndTime = e.StartsAt.Format(TimeLayout), e.EndsAt.Format(TimeLayout)
+}
+
+// dateOf returns midnight UTC of the wall-clock date of t, which is only used as a date value
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// combineDateAndClock joins the date of one time with the wall clock of another in loc
+func combineDateAndClock(date time.Time, clock time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, loc)
+}
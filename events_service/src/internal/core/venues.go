@@ -0,0 +1,76 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Venue represents a place events are held at. Shared venues can be used by
+// every organizer; the others only by the organizer owning them.
+type Venue struct {
+	VenueID     int       `json:"venue_id"`
+	Name        string    `json:"name"`
+	Address     string    `json:"address"`
+	MaxCapacity int       `json:"max_capacity"`
+	OrganizerID int       `json:"organizer_id"` // Owner, the only organizer allowed to edit it
+	Shared      bool      `json:"shared"`
+	Latitude    *float64  `json:"latitude,omitempty"` // Unset for venues without a known position
+	Longitude   *float64  `json:"longitude,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// CreateVenueRequest represents the request to create a venue
+type CreateVenueRequest struct {
+	Name        string   `json:"name" validate:"required"`
+	Address     string   `json:"address"`
+	MaxCapacity int      `json:"max_capacity" validate:"required,min=1"`
+	Shared      bool     `json:"shared"`
+	Latitude    *float64 `json:"latitude,omitempty"`
+	Longitude   *float64 `json:"longitude,omitempty"`
+}
+
+// UpdateVenueRequest represents the request to update a venue
+type UpdateVenueRequest struct {
+	Name        string   `json:"name,omitempty"`
+	Address     string   `json:"address,omitempty"`
+	MaxCapacity int      `json:"max_capacity,omitempty"`
+	Shared      *bool    `json:"shared,omitempty"`
+	Latitude    *float64 `json:"latitude,omitempty"` // Set together with Longitude
+	Longitude   *float64 `json:"longitude,omitempty"`
+}
+
+// VenueFilters represents filters for venue listing
+type VenueFilters struct {
+	OrganizerID int    // Owner's venues plus shared ones; 0 lists shared venues only
+	Name        string // Substring of the normalized name
+}
+
+// NormalizeVenueName folds case and whitespace so "Hall A" and " hall  a "
+// name the same venue. Migration 8 applies the same rule in SQL.
+func NormalizeVenueName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Validate checks the fields of a create request
+func (r *CreateVenueRequest) Validate() error {
+	r.Name = strings.Join(strings.Fields(r.Name), " ")
+	r.Address = strings.TrimSpace(r.Address)
+	if r.Name == "" {
+		return fmt.Errorf("venue name is required")
+	}
+	if r.MaxCapacity <= 0 {
+		return fmt.Errorf("max capacity must be greater than 0")
+	}
+	return ValidateCoordinates(r.Latitude, r.Longitude)
+}
+
+// VenueRepository defines the interface for venue data operations
+type VenueRepository interface {
+	CreateVenue(venue *Venue) (*Venue, error)
+	GetVenueByID(venueID int) (*Venue, error)
+	GetVenues(filters *VenueFilters) ([]Venue, error)
+	UpdateVenue(venueID int, request *UpdateVenueRequest, organizerID int) (*Venue, error)
+	DeleteVenue(venueID int, organizerID int) error
+}
@@ -3,6 +3,7 @@ package routes
 import (
 	"eventservice/src/internal/interfaces/input/grpc/middleware"
 	"eventservice/src/internal/interfaces/input/rest/handler/event"
+	"eventservice/src/internal/interfaces/input/rest/handler/venue"
 	"net/http"
 
 	pb "eventservice/src/internal/interfaces/input/grpc/generated"
@@ -10,7 +11,7 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
-func InitRoutes(eventHandler *event.EventHandler, grpcClient pb.ValidationServiceClient) http.Handler {
+func InitRoutes(eventHandler *event.EventHandler, venueHandler *venue.VenueHandler, grpcClient pb.ValidationServiceClient) http.Handler {
 	router := chi.NewRouter()
 
 	// Initialize session auth middleware
@@ -21,19 +22,30 @@ func InitRoutes(eventHandler *event.EventHandler, grpcClient pb.ValidationServic
 
 		// Public events routes
 		r.Route("/events", func(r chi.Router) {
-			r.Get("/", eventHandler.GetAllEvents) // Get all events with filters
-			// ^Filter not working properly
-			r.Get("/{id}", eventHandler.GetEvent) // Get specific event
+			r.Get("/", eventHandler.GetAllEvents)                            // Get a page of events with filters
+			r.With(sessionAuth.Optional).Get("/{id}", eventHandler.GetEvent) // Get specific event; drafts need a team session
 
 			// Protected event routes (authentication required)
 			r.Group(func(r chi.Router) {
 				r.Use(sessionAuth.Middleware) // Apply session validation
 				// Customer routes
 				r.With(sessionAuth.CustomerOnly).Post("/{id}/join", eventHandler.JoinEvent)
+				r.With(sessionAuth.CustomerOnly).Post("/{id}/hold", eventHandler.HoldSeats)
 				r.With(sessionAuth.CustomerOnly).Delete("/{id}/leave", eventHandler.LeaveEvent)
+				r.With(sessionAuth.CustomerOnly).Delete("/{id}/waitlist", eventHandler.LeaveWaitlist)
+				r.With(sessionAuth.CustomerOnly).Get("/{id}/promo-codes/{code}", eventHandler.ValidatePromoCode)
 			})
 		})
 
+		// Calendar subscriptions, authenticated by the secret token in the URL
+		r.Get("/feeds/{token}", eventHandler.GetCalendarFeed)
+
+		// Public venue routes (shared venues only)
+		r.Route("/venues", func(r chi.Router) {
+			r.Get("/", venueHandler.GetVenues)    // List shared venues
+			r.Get("/{id}", venueHandler.GetVenue) // Get specific shared venue
+		})
+
 		// Protected routes
 		r.Group(func(r chi.Router) {
 			r.Use(sessionAuth.Middleware)
@@ -41,19 +53,73 @@ func InitRoutes(eventHandler *event.EventHandler, grpcClient pb.ValidationServic
 			// Customer Routes
 			r.Route("/user", func(r chi.Router) {
 				r.Use(sessionAuth.CustomerOnly)
-				r.Get("/bookings", eventHandler.GetMyBookings) // Get user's booked events
+				r.Get("/bookings", eventHandler.GetMyBookings)                    // Get user's booked events
+				r.Get("/bookings/{id}/ticket", eventHandler.GetMyTicket)          // Get signed ticket of a booking
+				r.Get("/bookings/{id}/ticket/qr", eventHandler.GetMyTicketQRCode) // Get ticket as QR PNG
+				r.Get("/waitlist", eventHandler.GetMyWaitlist)                    // Get user's waitlist positions
+				r.Get("/holds", eventHandler.GetMyHolds)                          // Get user's active seat holds
+				r.Post("/holds/{id}/confirm", eventHandler.ConfirmHold)           // Book the held seats
+				r.Delete("/holds/{id}", eventHandler.ReleaseHold)                 // Give up a hold early
+				r.Get("/refunds", eventHandler.GetMyRefunds)                      // Get refunds of paid bookings
+				r.Get("/late-cancellations", eventHandler.GetMyLateCancellations) // Get seats released after a cutoff
+
+				// Calendar feed of the user's bookings
+				r.Get("/calendar-feed", eventHandler.GetMyCalendarFeed)
+				r.Post("/calendar-feed", eventHandler.CreateMyCalendarFeed)
+				r.Post("/calendar-feed/rotate", eventHandler.RotateMyCalendarFeed)
+				r.Delete("/calendar-feed", eventHandler.RevokeMyCalendarFeed)
 			})
 
 			// Organizer-specific routes
 			r.Route("/organizer", func(r chi.Router) {
 				r.Use(sessionAuth.OrganizerOnly)
-				r.Post("/events", eventHandler.CreateEvent)        // Create event
-				r.Get("/events", eventHandler.GetMyEvents)         // Get organizer's events
-				r.Put("/events/{id}", eventHandler.UpdateEvent)    // Update event
-				r.Delete("/events/{id}", eventHandler.DeleteEvent) // Delete event
+				r.Post("/events", eventHandler.CreateEvent)               // Create event
+				r.Get("/events", eventHandler.GetMyEvents)                // Get organizer's events
+				r.Put("/events/{id}", eventHandler.UpdateEvent)           // Update event
+				r.Delete("/events/{id}", eventHandler.DeleteEvent)        // Delete event
+				r.Post("/events/{id}/publish", eventHandler.PublishEvent) // Publish a draft
+				r.Post("/events/{id}/cancel", eventHandler.CancelEvent)   // Cancel, keeping bookings
+				r.Post("/series", eventHandler.CreateSeries)              // Create recurring series
+				r.Post("/events/import", eventHandler.ImportEvents)       // Create events from CSV
 
 				// Event participants
-				r.Get("/events/{id}/participants", eventHandler.GetEventParticipants) // Get event participants
+				r.Get("/events/{id}/participants", eventHandler.GetEventParticipants)            // Get event participants
+				r.Get("/events/{id}/participants/export", eventHandler.ExportEventParticipants)  // Participants as CSV or XLSX
+				r.Get("/participants/export", eventHandler.ExportMyParticipants)                 // Participants of all events
+				r.Get("/events/{id}/waitlist", eventHandler.GetEventWaitlist)                    // Get event waitlist
+				r.Get("/events/{id}/late-cancellations", eventHandler.GetEventLateCancellations) // Seats released after the cutoff
+				r.Post("/events/{id}/check-in", eventHandler.CheckInTicket)                      // Scan and admit a ticket
+				r.Get("/events/{id}/check-in/manifest", eventHandler.GetCheckInManifest)         // Signed manifest for offline check-in
+				r.Post("/events/{id}/check-in/sync", eventHandler.SyncCheckIns)                  // Upload offline check-ins
+				r.Get("/check-in/key", eventHandler.GetManifestKey)                              // Key to verify manifests with
+
+				// Event team
+				r.Get("/events/{id}/team", eventHandler.GetEventTeam)                      // List the event's organizers and roles
+				r.Post("/events/{id}/team", eventHandler.AddTeamMember)                    // Invite an organizer
+				r.Put("/events/{id}/team/{organizerID}", eventHandler.UpdateTeamMember)    // Change a member's role
+				r.Delete("/events/{id}/team/{organizerID}", eventHandler.RemoveTeamMember) // Remove a member or leave
+
+				// Booking analytics
+				r.Get("/analytics", eventHandler.GetMyAnalytics)                // Across the organizer's events
+				r.Get("/events/{id}/analytics", eventHandler.GetEventAnalytics) // One event
+
+				// Promo codes
+				r.Post("/promo-codes", eventHandler.CreatePromoCode)                     // Create a code for one or all events
+				r.Get("/promo-codes", eventHandler.GetMyPromoCodes)                      // List codes with their uses
+				r.Delete("/promo-codes/{id}", eventHandler.DeactivatePromoCode)          // Stop a code applying
+				r.Get("/promo-codes/{id}/redemptions", eventHandler.GetPromoRedemptions) // Bookings a code was applied to
+
+				// Calendar feed of the organizer's events
+				r.Get("/calendar-feed", eventHandler.GetMyCalendarFeed)
+				r.Post("/calendar-feed", eventHandler.CreateMyCalendarFeed)
+				r.Post("/calendar-feed/rotate", eventHandler.RotateMyCalendarFeed)
+				r.Delete("/calendar-feed", eventHandler.RevokeMyCalendarFeed)
+
+				// Venues
+				r.Post("/venues", venueHandler.CreateVenue)        // Create venue
+				r.Get("/venues", venueHandler.GetMyVenues)         // Get own and shared venues
+				r.Put("/venues/{id}", venueHandler.UpdateVenue)    // Update own venue
+				r.Delete("/venues/{id}", venueHandler.DeleteVenue) // Delete unused own venue
 			})
 		})
 	})
@@ -86,6 +86,42 @@ func (m *SessionAuthMiddleware) Middleware(next http.Handler) http.Handler {
 	})
 }
 
+// Optional identifies the caller like Middleware when a valid session is sent,
+// but lets requests without one through anonymously
+func (m *SessionAuthMiddleware) Optional(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID := ""
+		if cookie, err := r.Cookie("sess"); err == nil {
+			sessionID = cookie.Value
+		}
+		if sessionID == "" {
+			sessionID = r.Header.Get("Session-Id")
+		}
+		if sessionID == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		resp, err := m.GrpcClient.ValidateSession(context.Background(), &pb.ValidateSessionRequest{
+			SessionId: sessionID,
+		})
+		if err != nil || !resp.Valid {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userID, err := strconv.Atoi(resp.UserId)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), "userID", userID)
+		ctx = context.WithValue(ctx, "role", resp.Role)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // OrganizerOnly middleware ensures only organizers can access certain endpoints
 func (m *SessionAuthMiddleware) OrganizerOnly(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
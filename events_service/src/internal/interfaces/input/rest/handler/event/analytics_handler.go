@@ -0,0 +1,70 @@
+package event
+
+import (
+	"eventservice/src/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetMyAnalytics handles GET /organizer/analytics. Query parameters: from and
+// to (YYYY-MM-DD, inclusive) bound the events' start dates, tz (or the
+// X-Timezone header) sets the zone bookings are bucketed in and event_id
+// narrows the report to one event.
+func (eh *EventHandler) GetMyAnalytics(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventID := 0
+	if eventIDStr := r.URL.Query().Get("event_id"); eventIDStr != "" {
+		var err error
+		eventID, err = strconv.Atoi(eventIDStr)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+			return
+		}
+	}
+
+	eh.writeAnalytics(w, r, organizerID, eventID)
+}
+
+// GetEventAnalytics handles GET /organizer/events/{id}/analytics
+func (eh *EventHandler) GetEventAnalytics(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	eh.writeAnalytics(w, r, organizerID, eventID)
+}
+
+// writeAnalytics computes the report a request asks for and writes it
+func (eh *EventHandler) writeAnalytics(w http.ResponseWriter, r *http.Request, organizerID int, eventID int) {
+	query := r.URL.Query()
+	timeZone := query.Get("tz")
+	if timeZone == "" {
+		timeZone = r.Header.Get("X-Timezone")
+	}
+
+	analytics, err := eh.eventService.GetOrganizerAnalytics(organizerID, eventID, query.Get("from"), query.Get("to"), timeZone)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Analytics retrieved successfully", analytics)
+}
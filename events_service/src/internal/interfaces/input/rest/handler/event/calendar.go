@@ -0,0 +1,67 @@
+package event
+
+import (
+	"eventservice/src/internal/core"
+	"eventservice/src/pkg/ical"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// calendarProdID identifies this service in exported calendars
+const calendarProdID = "-//EventManagement//Events Service//EN"
+
+// calendarUIDDomain makes event UIDs globally unique; it must never change
+const calendarUIDDomain = "events.eventmanagement"
+
+// calendarFormat reports whether the client asked for iCalendar output, either
+// with ?format=ics or an Accept header naming text/calendar. JSON stays the default.
+func calendarFormat(r *http.Request) (bool, error) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "ics":
+		return true, nil
+	case "json":
+		return false, nil
+	case "":
+		return strings.Contains(r.Header.Get("Accept"), "text/calendar"), nil
+	default:
+		return false, fmt.Errorf("invalid format '%s'. Use json or ics", format)
+	}
+}
+
+// writeCalendar writes events as an .ics download
+func writeCalendar(w http.ResponseWriter, name string, filename string, events []core.Event) {
+	calendar := ical.Calendar{ProdID: calendarProdID, Name: name}
+	for _, event := range events {
+		calendar.Events = append(calendar.Events, calendarEvent(&event))
+	}
+
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(calendar.Encode())
+}
+
+// calendarEvent maps an event to a VEVENT
+func calendarEvent(event *core.Event) ical.Event {
+	status := ical.StatusConfirmed
+	switch {
+	case event.Status == core.EventStatusCancelled || event.BookingStatus == core.BookingStatusCancelledByOrganizer:
+		status = ical.StatusCancelled
+	case event.Status == core.EventStatusDraft:
+		status = ical.StatusTentative
+	}
+
+	return ical.Event{
+		UID:          fmt.Sprintf("event-%d@%s", event.EventID, calendarUIDDomain),
+		Sequence:     event.Sequence,
+		Summary:      event.EventName,
+		Description:  event.Description,
+		Location:     event.Place,
+		Start:        event.StartsAt,
+		End:          event.EndsAt,
+		Status:       status,
+		Created:      event.CreatedAt,
+		LastModified: event.UpdatedAt,
+	}
+}
@@ -0,0 +1,52 @@
+package event
+
+import (
+	"eventservice/src/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetMyLateCancellations handles GET /user/late-cancellations
+func (eh *EventHandler) GetMyLateCancellations(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	cancellations, err := eh.eventService.GetUserLateCancellations(userID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Late cancellations retrieved successfully", cancellations)
+}
+
+// GetEventLateCancellations handles GET /organizer/events/{id}/late-cancellations
+func (eh *EventHandler) GetEventLateCancellations(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	cancellations, err := eh.eventService.GetEventLateCancellations(eventID, organizerID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Late cancellations retrieved successfully", cancellations)
+}
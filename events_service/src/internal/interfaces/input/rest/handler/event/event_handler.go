@@ -2,12 +2,16 @@ package event
 
 import (
 	"encoding/json"
+	"errors"
 	"eventservice/src/internal/core"
 	eventservice "eventservice/src/internal/usecase/event"
 	"eventservice/src/pkg/response"
+	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -35,24 +39,68 @@ func (eh *EventHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Basic validation
-	if request.EventName == "" || request.Place == "" ||
-		request.EventDate == "" || request.StartTime == "" || request.EndTime == "" || request.Capacity <= 0 {
+	// Basic validation; tiered events may leave capacity to the sum of their tiers
+	if request.EventName == "" || (request.Place == "" && request.VenueID == 0) ||
+		!hasTimeRange(&request) || (request.Capacity <= 0 && len(request.Tiers) == 0) {
 		response.WriteError(w, http.StatusBadRequest, "All fields are required and capacity must be positive")
 		return
 	}
 
 	eventResponse, err := eh.eventService.CreateEvent(&request, organizerID)
 	if err != nil {
-		response.WriteError(w, http.StatusBadRequest, err.Error())
+		writeEventWriteError(w, err)
 		return
 	}
 
 	response.WriteSuccess(w, http.StatusCreated, "Event created successfully", eventResponse)
 }
 
-// GetEvent handles GET /events/{id}
+// hasTimeRange reports whether a create request carries either starts_at and
+// ends_at or event_date with start_time and end_time
+func hasTimeRange(request *core.CreateEventRequest) bool {
+	if request.StartsAt != "" || request.EndsAt != "" {
+		return request.StartsAt != "" && request.EndsAt != ""
+	}
+	return request.EventDate != "" && request.StartTime != "" && request.EndTime != ""
+}
+
+// CreateSeries handles POST /organizer/series
+func (eh *EventHandler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var request core.CreateSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Basic validation; the event's time range is the first occurrence
+	if request.EventName == "" || (request.Place == "" && request.VenueID == 0) || request.RRule == "" ||
+		!hasTimeRange(&request.CreateEventRequest) || (request.Capacity <= 0 && len(request.Tiers) == 0) {
+		response.WriteError(w, http.StatusBadRequest, "All fields including rrule are required and capacity must be positive")
+		return
+	}
+
+	seriesResponse, err := eh.eventService.CreateSeries(&request, organizerID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusCreated, "Series created successfully", seriesResponse)
+}
+
+// GetEvent handles GET /events/{id}. Drafts are only returned to organizers
+// on the event's team.
 func (eh *EventHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
+	// Set by the optional auth middleware when the caller is signed in
+	viewerID, _ := r.Context().Value("userID").(int)
+
 	eventIDStr := chi.URLParam(r, "id")
 	eventID, err := strconv.Atoi(eventIDStr)
 	if err != nil {
@@ -60,11 +108,28 @@ func (eh *EventHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	event, err := eh.eventService.GetEventByID(eventID)
+	loc, err := clientTimeZone(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	asCalendar, err := calendarFormat(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	event, err := eh.eventService.GetVisibleEvent(eventID, viewerID)
 	if err != nil {
 		response.WriteError(w, http.StatusNotFound, err.Error())
 		return
 	}
+	if asCalendar {
+		writeCalendar(w, event.EventName, fmt.Sprintf("event-%d.ics", event.EventID), []core.Event{*event})
+		return
+	}
+	localizeEvent(event, loc)
 
 	response.WriteSuccess(w, http.StatusOK, "Event retrieved successfully", event)
 }
@@ -72,33 +137,102 @@ func (eh *EventHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
 // GetAllEvents handles GET /events
 func (eh *EventHandler) GetAllEvents(w http.ResponseWriter, r *http.Request) {
 	// Build filters from query parameters
+	query := r.URL.Query()
 	filters := &core.EventFilters{
-		Date:        r.URL.Query().Get("date"),
-		Place:       r.URL.Query().Get("place"),
-		OrganizerID: 0, // Will be set below if provided
+		Query:  query.Get("q"),
+		Date:   query.Get("date"),
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Place:  query.Get("place"),
+		Status: query.Get("status"),
+		Sort:   query.Get("sort"),
+		Order:  query.Get("order"),
+		Cursor: query.Get("cursor"),
+	}
+
+	// Parse numeric filters if provided
+	numbers := []struct {
+		param string
+		dest  *int
+	}{
+		{"organizer_id", &filters.OrganizerID},
+		{"venue_id", &filters.VenueID},
+		{"min_capacity", &filters.MinCapacity},
+		{"max_capacity", &filters.MaxCapacity},
+		{"limit", &filters.Limit},
+	}
+	for _, number := range numbers {
+		if value := query.Get(number.param); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				response.WriteError(w, http.StatusBadRequest, "Invalid "+number.param)
+				return
+			}
+			*number.dest = parsed
+		}
 	}
 
-	// Parse organizer ID if provided
-	if organizerIDStr := r.URL.Query().Get("organizer_id"); organizerIDStr != "" {
-		if organizerID, err := strconv.Atoi(organizerIDStr); err == nil {
-			filters.OrganizerID = organizerID
+	// Parse boolean filters if provided
+	flags := []struct {
+		param string
+		dest  *bool
+	}{
+		{"upcoming", &filters.Upcoming},
+		{"past", &filters.Past},
+		{"has_seats", &filters.HasSeats},
+	}
+	for _, flag := range flags {
+		if value := query.Get(flag.param); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				response.WriteError(w, http.StatusBadRequest, "Invalid "+flag.param)
+				return
+			}
+			*flag.dest = parsed
 		}
 	}
 
-	// Parse organizer_id if provided
-	if organizerIDStr := r.URL.Query().Get("organizer_id"); organizerIDStr != "" {
-		if organizerID, err := strconv.Atoi(organizerIDStr); err == nil {
-			filters.OrganizerID = organizerID
+	// Parse the near point and search radius if provided
+	if near := query.Get("near"); near != "" {
+		point, err := core.ParseGeoPoint(near)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		filters.Near = point
+	}
+	if radius := query.Get("radius_km"); radius != "" {
+		radiusKm, err := strconv.ParseFloat(radius, 64)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "Invalid radius_km")
+			return
 		}
+		filters.RadiusKm = radiusKm
 	}
 
-	events, err := eh.eventService.GetAllEvents(filters)
+	// The date filter and the returned times follow the client's zone when given
+	loc, err := clientTimeZone(r)
 	if err != nil {
 		response.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if loc != nil {
+		filters.TimeZone = loc.String()
+	}
 
-	response.WriteSuccess(w, http.StatusOK, "Events retrieved successfully", events)
+	page, err := eh.eventService.GetAllEvents(filters)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if loc != nil {
+		for i := range page.Events {
+			page.Events[i].Localize(loc)
+			page.Events[i].DisplayTimeZone = loc.String()
+		}
+	}
+
+	response.WritePage(w, http.StatusOK, "Events retrieved successfully", page.Events, page.NextCursor)
 }
 
 // GetMyEvents handles GET /organizer/events (for organizers to see their events)
@@ -110,11 +244,36 @@ func (eh *EventHandler) GetMyEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	events, err := eh.eventService.GetEventsByOrganizer(organizerID)
+	status := r.URL.Query().Get("status")
+	if status != "" && !core.IsValidEventStatus(status) {
+		response.WriteError(w, http.StatusBadRequest, "Invalid status filter")
+		return
+	}
+
+	loc, err := clientTimeZone(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	asCalendar, err := calendarFormat(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	events, err := eh.eventService.GetEventsByOrganizer(organizerID, status)
 	if err != nil {
 		response.WriteError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if asCalendar {
+		writeCalendar(w, "My events", "events.ics", events)
+		return
+	}
+	for i := range events {
+		localizeEvent(&events[i], loc)
+	}
 
 	response.WriteSuccess(w, http.StatusOK, "Events retrieved successfully", events)
 }
@@ -141,9 +300,21 @@ func (eh *EventHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Occurrences of a series can be edited together
+	if scope := r.URL.Query().Get("scope"); scope != "" && scope != core.SeriesScopeThis {
+		events, err := eh.eventService.UpdateSeriesEvents(eventID, &request, organizerID, scope)
+		if err != nil {
+			writeEventWriteError(w, err)
+			return
+		}
+
+		response.WriteSuccess(w, http.StatusOK, "Events updated successfully", events)
+		return
+	}
+
 	eventResponse, err := eh.eventService.UpdateEvent(eventID, &request, organizerID)
 	if err != nil {
-		response.WriteError(w, http.StatusBadRequest, err.Error())
+		writeEventWriteError(w, err)
 		return
 	}
 
@@ -166,7 +337,12 @@ func (eh *EventHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = eh.eventService.DeleteEvent(eventID, organizerID)
+	// Occurrences of a series can be deleted together
+	if scope := r.URL.Query().Get("scope"); scope != "" && scope != core.SeriesScopeThis {
+		err = eh.eventService.DeleteSeriesEvents(eventID, organizerID, scope)
+	} else {
+		err = eh.eventService.DeleteEvent(eventID, organizerID)
+	}
 	if err != nil {
 		response.WriteError(w, http.StatusBadRequest, err.Error())
 		return
@@ -175,6 +351,56 @@ func (eh *EventHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	response.WriteSuccess(w, http.StatusOK, "Event deleted successfully", nil)
 }
 
+// PublishEvent handles POST /organizer/events/{id}/publish
+func (eh *EventHandler) PublishEvent(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	eventResponse, err := eh.eventService.PublishEvent(eventID, organizerID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Event published successfully", eventResponse)
+}
+
+// CancelEvent handles POST /organizer/events/{id}/cancel
+func (eh *EventHandler) CancelEvent(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	eventResponse, err := eh.eventService.CancelEvent(eventID, organizerID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Event cancelled successfully", eventResponse)
+}
+
 // JoinEvent handles POST /events/{id}/join
 func (eh *EventHandler) JoinEvent(w http.ResponseWriter, r *http.Request) {
 	log.Printf("DEBUG: JoinEvent handler called for path: %s", r.URL.Path)
@@ -199,9 +425,13 @@ func (eh *EventHandler) JoinEvent(w http.ResponseWriter, r *http.Request) {
 
 	log.Printf("DEBUG: Event ID: %d", eventID)
 
-	// For now, use placeholder values for email and username
-	// In production, these should come from the auth service via gRPC
-	request := &core.JoinEventRequest{EventID: eventID}
+	// The body is optional and only carries booking options such as the tier
+	request := &core.JoinEventRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil && err != io.EOF {
+		response.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	request.EventID = eventID
 	log.Printf("DEBUG: Calling eventService.JoinEventWithRequest")
 
 	bookingResponse, err := eh.eventService.JoinEventWithRequest(userID, request)
@@ -212,10 +442,10 @@ func (eh *EventHandler) JoinEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Printf("DEBUG: JoinEventWithRequest successful: %+v", bookingResponse)
-	response.WriteSuccess(w, http.StatusOK, "Successfully joined event", bookingResponse)
+	response.WriteSuccess(w, http.StatusOK, bookingResponse.Message, bookingResponse)
 }
 
-// LeaveEvent handles DELETE /events/{id}/leave
+// LeaveEvent handles DELETE /events/{id}/leave?seats=N
 func (eh *EventHandler) LeaveEvent(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("userID").(int)
@@ -231,16 +461,27 @@ func (eh *EventHandler) LeaveEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = eh.eventService.LeaveEvent(userID, eventID)
+	// Without seats the whole booking is cancelled
+	seats := 0
+	if seatsStr := r.URL.Query().Get("seats"); seatsStr != "" {
+		seats, err = strconv.Atoi(seatsStr)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "Invalid seats")
+			return
+		}
+	}
+
+	leaveResponse, err := eh.eventService.LeaveEvent(userID, eventID, seats)
 	if err != nil {
 		response.WriteError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	response.WriteSuccess(w, http.StatusOK, "Successfully left event", nil)
+	response.WriteSuccess(w, http.StatusOK, leaveResponse.Message, leaveResponse)
 }
 
-// GetMyBookings handles GET /user/bookings (for users to see their joined events)
+// GetMyBookings handles GET /user/bookings (for users to see their joined events);
+// ?format=ics returns them as a calendar
 func (eh *EventHandler) GetMyBookings(w http.ResponseWriter, r *http.Request) {
 	// Get user ID from context (set by auth middleware)
 	userID, ok := r.Context().Value("userID").(int)
@@ -249,11 +490,30 @@ func (eh *EventHandler) GetMyBookings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	loc, err := clientTimeZone(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	asCalendar, err := calendarFormat(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	events, err := eh.eventService.GetUserBookings(userID)
 	if err != nil {
 		response.WriteError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	if asCalendar {
+		writeCalendar(w, "My bookings", "bookings.ics", events)
+		return
+	}
+	for i := range events {
+		localizeEvent(&events[i], loc)
+	}
 
 	response.WriteSuccess(w, http.StatusOK, "Bookings retrieved successfully", events)
 }
@@ -282,3 +542,127 @@ func (eh *EventHandler) GetEventParticipants(w http.ResponseWriter, r *http.Requ
 
 	response.WriteSuccess(w, http.StatusOK, "Participants retrieved successfully", participants)
 }
+
+// LeaveWaitlist handles DELETE /events/{id}/waitlist
+func (eh *EventHandler) LeaveWaitlist(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	err = eh.eventService.LeaveWaitlist(userID, eventID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Successfully left waitlist", nil)
+}
+
+// GetMyRefunds handles GET /user/refunds
+func (eh *EventHandler) GetMyRefunds(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	refunds, err := eh.eventService.GetUserRefunds(userID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Refunds retrieved successfully", refunds)
+}
+
+// GetMyWaitlist handles GET /user/waitlist (for users to see their queue positions)
+func (eh *EventHandler) GetMyWaitlist(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	entries, err := eh.eventService.GetUserWaitlist(userID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Waitlist retrieved successfully", entries)
+}
+
+// GetEventWaitlist handles GET /organizer/events/{id}/waitlist (for organizers)
+func (eh *EventHandler) GetEventWaitlist(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	entries, err := eh.eventService.GetEventWaitlist(eventID, organizerID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Waitlist retrieved successfully", entries)
+}
+
+// clientTimeZone reads the zone a client wants times rendered in from the tz
+// query parameter or the X-Timezone header. It returns nil when neither is
+// set, in which case times stay in each event's own zone.
+func clientTimeZone(r *http.Request) (*time.Location, error) {
+	name := r.URL.Query().Get("tz")
+	if name == "" {
+		name = r.Header.Get("X-Timezone")
+	}
+	if name == "" {
+		return nil, nil
+	}
+	return core.LoadTimeZone(name)
+}
+
+// localizeEvent converts an event's times to the client's zone, if one was requested
+func localizeEvent(event *core.Event, loc *time.Location) {
+	if loc == nil {
+		return
+	}
+	event.Localize(loc)
+	event.DisplayTimeZone = loc.String()
+}
+
+// writeEventWriteError reports a failed create or update. Venue overlaps are a
+// 409 carrying the conflicting event; anything else is a bad request.
+func writeEventWriteError(w http.ResponseWriter, err error) {
+	var venueErr *core.VenueUnavailableError
+	if errors.As(err, &venueErr) {
+		response.WriteResponse(w, http.StatusConflict, response.StandardResponse{
+			Status:  "error",
+			Message: err.Error(),
+			Data:    venueErr,
+		})
+		return
+	}
+	response.WriteError(w, http.StatusBadRequest, err.Error())
+}
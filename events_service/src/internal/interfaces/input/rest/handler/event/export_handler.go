@@ -0,0 +1,122 @@
+package event
+
+import (
+	"eventservice/src/internal/core"
+	"eventservice/src/pkg/response"
+	"eventservice/src/pkg/spreadsheet"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// exportFormat reads ?format=csv|xlsx, defaulting to CSV
+func exportFormat(r *http.Request) (string, error) {
+	switch format := r.URL.Query().Get("format"); format {
+	case "", spreadsheet.FormatCSV:
+		return spreadsheet.FormatCSV, nil
+	case spreadsheet.FormatXLSX:
+		return spreadsheet.FormatXLSX, nil
+	default:
+		return "", fmt.Errorf("invalid format '%s'. Use csv or xlsx", format)
+	}
+}
+
+// writeExport streams a participant table as a spreadsheet download. Headers
+// are sent first, so later write failures can only be logged.
+func writeExport(w http.ResponseWriter, format string, filename string, export *core.ParticipantExport) {
+	w.Header().Set("Content-Type", spreadsheet.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	sheet, err := spreadsheet.NewWriter(format, w, "Participants")
+	if err != nil {
+		log.Printf("failed to export participants: %v", err)
+		return
+	}
+	if err := sheet.WriteHeader(export.Header); err != nil {
+		log.Printf("failed to export participants: %v", err)
+		return
+	}
+	for _, row := range export.Rows {
+		if err := sheet.WriteRow(row); err != nil {
+			log.Printf("failed to export participants: %v", err)
+			return
+		}
+	}
+	if err := sheet.Close(); err != nil {
+		log.Printf("failed to export participants: %v", err)
+	}
+}
+
+// ExportEventParticipants handles GET /organizer/events/{id}/participants/export
+// with ?format=csv|xlsx and an optional comma separated ?columns= list
+func (eh *EventHandler) ExportEventParticipants(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	format, err := exportFormat(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	loc, err := clientTimeZone(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	export, err := eh.eventService.ExportEventParticipants(eventID, organizerID, r.URL.Query().Get("columns"), loc)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeExport(w, format, fmt.Sprintf("event-%d-participants", eventID), export)
+}
+
+// ExportMyParticipants handles GET /organizer/participants/export, exporting the
+// participants of all the organizer's events in one sheet
+func (eh *EventHandler) ExportMyParticipants(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	format, err := exportFormat(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	loc, err := clientTimeZone(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	export, err := eh.eventService.ExportOrganizerParticipants(organizerID, r.URL.Query().Get("columns"), loc)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeExport(w, format, "participants", export)
+}
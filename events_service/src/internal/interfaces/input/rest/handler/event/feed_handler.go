@@ -0,0 +1,123 @@
+package event
+
+import (
+	"eventservice/src/internal/core"
+	"eventservice/src/pkg/response"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// feedKind picks the calendar feed of the signed in user: customers subscribe
+// to their bookings and organizers to their events
+func feedKind(r *http.Request) (string, bool) {
+	switch role, _ := r.Context().Value("role").(string); role {
+	case "customer":
+		return core.CalendarFeedBookings, true
+	case "organizer":
+		return core.CalendarFeedOrganizer, true
+	}
+	return "", false
+}
+
+// feedURL is the absolute subscription URL of a feed token
+func feedURL(r *http.Request, token string) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/api/v1/feeds/%s", scheme, r.Host, token)
+}
+
+// GetMyCalendarFeed handles GET /user/calendar-feed and GET /organizer/calendar-feed
+func (eh *EventHandler) GetMyCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(int)
+	kind, hasKind := feedKind(r)
+	if !ok || !hasKind {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	feed, err := eh.eventService.GetCalendarFeed(userID, kind)
+	if err != nil {
+		response.WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Calendar feed retrieved successfully", feed)
+}
+
+// CreateMyCalendarFeed handles POST /user/calendar-feed and POST /organizer/calendar-feed
+func (eh *EventHandler) CreateMyCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(int)
+	kind, hasKind := feedKind(r)
+	if !ok || !hasKind {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	feed, err := eh.eventService.CreateCalendarFeed(userID, kind)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	feed.URL = feedURL(r, feed.Token)
+
+	response.WriteSuccess(w, http.StatusCreated, "Calendar feed created successfully", feed)
+}
+
+// RotateMyCalendarFeed handles POST /user/calendar-feed/rotate and POST /organizer/calendar-feed/rotate
+func (eh *EventHandler) RotateMyCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(int)
+	kind, hasKind := feedKind(r)
+	if !ok || !hasKind {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	feed, err := eh.eventService.RotateCalendarFeed(userID, kind)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	feed.URL = feedURL(r, feed.Token)
+
+	response.WriteSuccess(w, http.StatusOK, "Calendar feed rotated successfully", feed)
+}
+
+// RevokeMyCalendarFeed handles DELETE /user/calendar-feed and DELETE /organizer/calendar-feed
+func (eh *EventHandler) RevokeMyCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value("userID").(int)
+	kind, hasKind := feedKind(r)
+	if !ok || !hasKind {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := eh.eventService.RevokeCalendarFeed(userID, kind); err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Calendar feed revoked successfully", nil)
+}
+
+// GetCalendarFeed handles GET /feeds/{token}. The token authenticates the
+// request, so calendar apps can poll it without a session.
+func (eh *EventHandler) GetCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	feed, events, err := eh.eventService.GetCalendarFeedEvents(chi.URLParam(r, "token"))
+	if err != nil {
+		response.WriteError(w, http.StatusNotFound, "Calendar feed not found")
+		return
+	}
+
+	name := "My bookings"
+	if feed.Kind == core.CalendarFeedOrganizer {
+		name = "My events"
+	}
+
+	// Feeds are personal; shared caches must not keep them
+	w.Header().Set("Cache-Control", "private, no-cache")
+	writeCalendar(w, name, "calendar.ics", events)
+}
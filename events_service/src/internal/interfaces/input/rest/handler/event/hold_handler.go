@@ -0,0 +1,123 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"eventservice/src/internal/core"
+	"eventservice/src/pkg/response"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// HoldSeats handles POST /events/{id}/hold. The optional body takes the same
+// booking options as joining; the seats stay reserved until the hold expires.
+func (eh *EventHandler) HoldSeats(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	request := &core.JoinEventRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil && err != io.EOF {
+		response.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	request.EventID = eventID
+
+	hold, err := eh.eventService.HoldSeats(userID, request)
+	if err != nil {
+		// Sold out events cannot be held; customers may join the waitlist instead
+		if errors.Is(err, core.ErrEventFull) {
+			response.WriteError(w, http.StatusConflict, err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusCreated, "Seats held successfully", hold)
+}
+
+// GetMyHolds handles GET /user/holds
+func (eh *EventHandler) GetMyHolds(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	holds, err := eh.eventService.GetSeatHolds(userID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Seat holds retrieved successfully", holds)
+}
+
+// ConfirmHold handles POST /user/holds/{id}/confirm. Holds on paid events take
+// the payment method and an optional promo code in the body.
+func (eh *EventHandler) ConfirmHold(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	holdID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid hold ID")
+		return
+	}
+
+	var request core.ConfirmHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil && err != io.EOF {
+		response.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	bookingResponse, err := eh.eventService.ConfirmSeatHold(userID, holdID, &request)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, bookingResponse.Message, bookingResponse)
+}
+
+// ReleaseHold handles DELETE /user/holds/{id}
+func (eh *EventHandler) ReleaseHold(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	holdID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid hold ID")
+		return
+	}
+
+	if err := eh.eventService.ReleaseSeatHold(userID, holdID); err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Seat hold released successfully", nil)
+}
@@ -0,0 +1,67 @@
+package event
+
+import (
+	"eventservice/src/internal/core"
+	"eventservice/src/pkg/response"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// ImportEvents handles POST /organizer/events/import. The CSV is sent either as
+// the request body or as the "file" field of a multipart form. ?mode= is
+// all_or_nothing (default) or valid_only and ?dry_run=true only validates.
+func (eh *EventHandler) ImportEvents(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "Invalid dry_run")
+			return
+		}
+		dryRun = parsed
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, core.MaxImportFileSize)
+	var body io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "CSV file is required in the 'file' field")
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	result, err := eh.eventService.ImportEvents(body, organizerID, r.URL.Query().Get("mode"), dryRun)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	switch {
+	case result.DryRun:
+		response.WriteSuccess(w, http.StatusOK, "Import validated, no events were created", result)
+	case !result.Committed:
+		message := "Import cancelled, fix the invalid rows or import in valid_only mode"
+		if result.Valid == 0 {
+			message = "No events were imported, every row is invalid"
+		}
+		response.WriteResponse(w, http.StatusUnprocessableEntity, response.StandardResponse{
+			Status:  "error",
+			Message: message,
+			Data:    result,
+		})
+	default:
+		response.WriteSuccess(w, http.StatusCreated, "Events imported successfully", result)
+	}
+}
@@ -0,0 +1,135 @@
+package event
+
+import (
+	"encoding/json"
+	"eventservice/src/internal/core"
+	"eventservice/src/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CreatePromoCode handles POST /organizer/promo-codes
+func (eh *EventHandler) CreatePromoCode(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var request core.CreatePromoCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	promo, err := eh.eventService.CreatePromoCode(organizerID, &request)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusCreated, "Promo code created successfully", promo)
+}
+
+// GetMyPromoCodes handles GET /organizer/promo-codes
+func (eh *EventHandler) GetMyPromoCodes(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	promos, err := eh.eventService.GetPromoCodes(organizerID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Promo codes retrieved successfully", promos)
+}
+
+// DeactivatePromoCode handles DELETE /organizer/promo-codes/{id}. The code
+// stops applying but its redemptions are kept.
+func (eh *EventHandler) DeactivatePromoCode(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	promoID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid promo code ID")
+		return
+	}
+
+	if err := eh.eventService.DeactivatePromoCode(organizerID, promoID); err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Promo code deactivated successfully", nil)
+}
+
+// GetPromoRedemptions handles GET /organizer/promo-codes/{id}/redemptions
+func (eh *EventHandler) GetPromoRedemptions(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	promoID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid promo code ID")
+		return
+	}
+
+	redemptions, err := eh.eventService.GetPromoRedemptions(organizerID, promoID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Promo redemptions retrieved successfully", redemptions)
+}
+
+// ValidatePromoCode handles GET /events/{id}/promo-codes/{code}. The optional
+// seats query parameter (default 1) sets the booking size the code is priced for.
+func (eh *EventHandler) ValidatePromoCode(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	seats := 0
+	if seatsStr := r.URL.Query().Get("seats"); seatsStr != "" {
+		seats, err = strconv.Atoi(seatsStr)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "Invalid seats")
+			return
+		}
+	}
+
+	quote, err := eh.eventService.ValidatePromoCode(userID, eventID, chi.URLParam(r, "code"), seats)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Promo code is valid", quote)
+}
@@ -0,0 +1,133 @@
+package event
+
+import (
+	"encoding/json"
+	"eventservice/src/internal/core"
+	"eventservice/src/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetEventTeam handles GET /organizer/events/{id}/team
+func (eh *EventHandler) GetEventTeam(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	team, err := eh.eventService.GetEventTeam(eventID, organizerID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Team retrieved successfully", team)
+}
+
+// AddTeamMember handles POST /organizer/events/{id}/team
+func (eh *EventHandler) AddTeamMember(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var request core.AddTeamMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	member, err := eh.eventService.AddTeamMember(eventID, &request, organizerID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusCreated, "Team member added successfully", member)
+}
+
+// UpdateTeamMember handles PUT /organizer/events/{id}/team/{organizerID}
+func (eh *EventHandler) UpdateTeamMember(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	memberID, err := strconv.Atoi(chi.URLParam(r, "organizerID"))
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid organizer ID")
+		return
+	}
+
+	var request core.UpdateTeamMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := eh.eventService.UpdateTeamMember(eventID, memberID, &request, organizerID); err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Team member updated successfully", nil)
+}
+
+// RemoveTeamMember handles DELETE /organizer/events/{id}/team/{organizerID}
+func (eh *EventHandler) RemoveTeamMember(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	memberID, err := strconv.Atoi(chi.URLParam(r, "organizerID"))
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid organizer ID")
+		return
+	}
+
+	if err := eh.eventService.RemoveTeamMember(eventID, memberID, organizerID); err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Team member removed successfully", nil)
+}
@@ -0,0 +1,212 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"eventservice/src/internal/core"
+	"eventservice/src/pkg/response"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetMyTicket handles GET /user/bookings/{id}/ticket
+func (eh *EventHandler) GetMyTicket(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	loc, err := clientTimeZone(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ticket, err := eh.eventService.GetTicket(userID, eventID)
+	if err != nil {
+		response.WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	// Times are shown in the client's zone, or else the event's
+	if loc == nil {
+		loc, err = core.LoadTimeZone(ticket.TimeZone)
+		if err != nil {
+			loc = time.UTC
+		}
+	}
+	ticket.StartsAt = ticket.StartsAt.In(loc)
+	ticket.EndsAt = ticket.EndsAt.In(loc)
+
+	response.WriteSuccess(w, http.StatusOK, "Ticket retrieved successfully", ticket)
+}
+
+// GetMyTicketQRCode handles GET /user/bookings/{id}/ticket/qr?size=N, returning a PNG
+func (eh *EventHandler) GetMyTicketQRCode(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from context (set by auth middleware)
+	userID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	size := 0
+	if sizeStr := r.URL.Query().Get("size"); sizeStr != "" {
+		size, err = strconv.Atoi(sizeStr)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "Invalid size")
+			return
+		}
+	}
+
+	png, err := eh.eventService.GetTicketQRCode(userID, eventID, size)
+	if errors.Is(err, core.ErrTicketsDisabled) {
+		response.WriteError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// The code admits to the event, so it must not linger in shared caches
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(png)
+}
+
+// CheckInTicket handles POST /organizer/events/{id}/check-in
+func (eh *EventHandler) CheckInTicket(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var request core.CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	checkIn, err := eh.eventService.CheckIn(eventID, &request, organizerID)
+	if errors.Is(err, core.ErrTicketsDisabled) {
+		response.WriteError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if err != nil {
+		// Reused tickets report when they were first admitted
+		var usedErr *core.TicketUsedError
+		if errors.As(err, &usedErr) {
+			response.WriteResponse(w, http.StatusConflict, response.StandardResponse{
+				Status:  "error",
+				Message: err.Error(),
+				Data:    usedErr,
+			})
+			return
+		}
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Checked in successfully", checkIn)
+}
+
+// GetCheckInManifest handles GET /organizer/events/{id}/check-in/manifest
+func (eh *EventHandler) GetCheckInManifest(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	manifest, err := eh.eventService.GetCheckInManifest(eventID, organizerID)
+	if errors.Is(err, core.ErrTicketsDisabled) {
+		response.WriteError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Manifest generated successfully", manifest)
+}
+
+// GetManifestKey handles GET /organizer/check-in/key
+func (eh *EventHandler) GetManifestKey(w http.ResponseWriter, r *http.Request) {
+	key, err := eh.eventService.GetManifestKey()
+	if err != nil {
+		response.WriteError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Manifest key retrieved successfully", key)
+}
+
+// SyncCheckIns handles POST /organizer/events/{id}/check-in/sync
+func (eh *EventHandler) SyncCheckIns(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	eventIDStr := chi.URLParam(r, "id")
+	eventID, err := strconv.Atoi(eventIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var request core.CheckInSyncRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	sync, err := eh.eventService.SyncCheckIns(eventID, &request, organizerID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Check-ins synced successfully", sync)
+}
@@ -0,0 +1,153 @@
+package venue
+
+import (
+	"encoding/json"
+	"eventservice/src/internal/core"
+	venueservice "eventservice/src/internal/usecase/venue"
+	"eventservice/src/pkg/response"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type VenueHandler struct {
+	venueService venueservice.Service
+}
+
+func NewVenueHandler(vs venueservice.Service) *VenueHandler {
+	return &VenueHandler{venueService: vs}
+}
+
+// CreateVenue handles POST /organizer/venues
+func (vh *VenueHandler) CreateVenue(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var request core.CreateVenueRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	venue, err := vh.venueService.CreateVenue(&request, organizerID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusCreated, "Venue created successfully", venue)
+}
+
+// GetVenues handles GET /venues (shared venues)
+func (vh *VenueHandler) GetVenues(w http.ResponseWriter, r *http.Request) {
+	filters := &core.VenueFilters{Name: r.URL.Query().Get("name")}
+
+	venues, err := vh.venueService.GetVenues(filters)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Venues retrieved successfully", venues)
+}
+
+// GetVenue handles GET /venues/{id} (shared venues)
+func (vh *VenueHandler) GetVenue(w http.ResponseWriter, r *http.Request) {
+	venueIDStr := chi.URLParam(r, "id")
+	venueID, err := strconv.Atoi(venueIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid venue ID")
+		return
+	}
+
+	venue, err := vh.venueService.GetVenueByID(venueID, 0)
+	if err != nil {
+		response.WriteError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Venue retrieved successfully", venue)
+}
+
+// GetMyVenues handles GET /organizer/venues (own and shared venues)
+func (vh *VenueHandler) GetMyVenues(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	filters := &core.VenueFilters{
+		OrganizerID: organizerID,
+		Name:        r.URL.Query().Get("name"),
+	}
+
+	venues, err := vh.venueService.GetVenues(filters)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Venues retrieved successfully", venues)
+}
+
+// UpdateVenue handles PUT /organizer/venues/{id}
+func (vh *VenueHandler) UpdateVenue(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	venueIDStr := chi.URLParam(r, "id")
+	venueID, err := strconv.Atoi(venueIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid venue ID")
+		return
+	}
+
+	var request core.UpdateVenueRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	venue, err := vh.venueService.UpdateVenue(venueID, &request, organizerID)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Venue updated successfully", venue)
+}
+
+// DeleteVenue handles DELETE /organizer/venues/{id}
+func (vh *VenueHandler) DeleteVenue(w http.ResponseWriter, r *http.Request) {
+	// Get organizer ID from context (set by auth middleware)
+	organizerID, ok := r.Context().Value("userID").(int)
+	if !ok {
+		response.WriteError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	venueIDStr := chi.URLParam(r, "id")
+	venueID, err := strconv.Atoi(venueIDStr)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "Invalid venue ID")
+		return
+	}
+
+	if err := vh.venueService.DeleteVenue(venueID, organizerID); err != nil {
+		response.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, "Venue deleted successfully", nil)
+}
@@ -0,0 +1,154 @@
+package event
+
+import (
+	"encoding/json"
+	"eventservice/src/internal/core"
+	"fmt"
+	"log"
+	"time"
+)
+
+// GetOrganizerAnalytics reports booking figures over the events the organizer
+// works on, or over one event when eventID is set. from and to (YYYY-MM-DD,
+// both inclusive) bound the events' start dates in timeZone, which is also the
+// zone bookings are bucketed by day and hour in. Reports covering many
+// bookings are cached for a few minutes.
+func (s *Service) GetOrganizerAnalytics(organizerID int, eventID int, from, to string, timeZone string) (*core.Analytics, error) {
+	loc, err := core.LoadTimeZone(timeZone)
+	if err != nil {
+		return nil, err
+	}
+	filters := core.AnalyticsFilters{EventID: eventID, TimeZone: loc.String()}
+	if from != "" {
+		start, err := time.ParseInLocation("2006-01-02", from, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid from date, use YYYY-MM-DD")
+		}
+		filters.From = &start
+	}
+	if to != "" {
+		end, err := time.ParseInLocation("2006-01-02", to, loc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid to date, use YYYY-MM-DD")
+		}
+		// The last day is included, so stop at the start of the next one
+		end = end.AddDate(0, 0, 1)
+		filters.To = &end
+	}
+	if filters.From != nil && filters.To != nil && !filters.From.Before(*filters.To) {
+		return nil, fmt.Errorf("from date must not be after to date")
+	}
+
+	if eventID != 0 {
+		if err := s.requirePermission(eventID, organizerID, core.PermissionView, "view its analytics"); err != nil {
+			return nil, err
+		}
+	}
+
+	key := analyticsCacheKey(organizerID, &filters)
+	if cached := s.cachedAnalytics(key); cached != nil {
+		return cached, nil
+	}
+
+	analytics, err := s.repo.GetOrganizerAnalytics(organizerID, &filters)
+	if err != nil {
+		return nil, err
+	}
+	summarizeAnalytics(analytics)
+	analytics.GeneratedAt = time.Now().UTC()
+
+	if s.cache != nil && analytics.Totals.Bookings >= core.AnalyticsCacheMinBookings {
+		if data, err := json.Marshal(analytics); err != nil {
+			log.Printf("Failed to encode analytics for caching: %v", err)
+		} else if err := s.cache.Set(key, data, core.AnalyticsCacheTTL); err != nil {
+			log.Printf("Failed to cache analytics: %v", err)
+		}
+	}
+
+	return analytics, nil
+}
+
+// cachedAnalytics returns a cached report, or nil when there is none. Cache
+// failures only cost a recomputation, so they are logged and ignored.
+func (s *Service) cachedAnalytics(key string) *core.Analytics {
+	if s.cache == nil {
+		return nil
+	}
+	data, found, err := s.cache.Get(key)
+	if err != nil {
+		log.Printf("Failed to read cached analytics: %v", err)
+		return nil
+	}
+	if !found {
+		return nil
+	}
+	var analytics core.Analytics
+	if err := json.Unmarshal(data, &analytics); err != nil {
+		log.Printf("Failed to decode cached analytics: %v", err)
+		return nil
+	}
+	analytics.Cached = true
+	return &analytics
+}
+
+// analyticsCacheKey identifies a report by everything it was computed over
+func analyticsCacheKey(organizerID int, filters *core.AnalyticsFilters) string {
+	bound := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("events:analytics:%d:%d:%s:%s:%s",
+		organizerID, filters.EventID, bound(filters.From), bound(filters.To), filters.TimeZone)
+}
+
+// summarizeAnalytics fills in the rates of each event, the totals over all of
+// them and the peak booking hours
+func summarizeAnalytics(analytics *core.Analytics) {
+	totals := &analytics.Totals
+	for i := range analytics.Events {
+		event := &analytics.Events[i]
+		fillAnalyticsRates(&event.AnalyticsTotals)
+
+		totals.Events++
+		totals.Bookings += event.Bookings
+		totals.BookedSeats += event.BookedSeats
+		totals.Cancellations += event.Cancellations
+		totals.CancelledSeats += event.CancelledSeats
+		totals.CheckedIn += event.CheckedIn
+		totals.NoShows += event.NoShows
+		// Cancelled events never get the chance to fill up
+		if event.Status != core.EventStatusCancelled {
+			totals.Capacity += event.Capacity
+			totals.Filled += event.Filled
+		}
+	}
+	fillAnalyticsRates(totals)
+
+	peak := 0
+	for _, hour := range analytics.Hourly {
+		if hour.Bookings > peak {
+			peak = hour.Bookings
+		}
+	}
+	if peak > 0 {
+		for _, hour := range analytics.Hourly {
+			if hour.Bookings == peak {
+				analytics.PeakHours = append(analytics.PeakHours, hour.Hour)
+			}
+		}
+	}
+}
+
+// fillAnalyticsRates computes the fill, check-in and no-show rates of totals
+func fillAnalyticsRates(totals *core.AnalyticsTotals) {
+	if totals.Capacity > 0 {
+		totals.FillRate = float64(totals.Filled) / float64(totals.Capacity)
+	}
+	if attending := totals.CheckedIn + totals.NoShows; attending > 0 {
+		checkInRate := float64(totals.CheckedIn) / float64(attending)
+		noShowRate := float64(totals.NoShows) / float64(attending)
+		totals.CheckInRate, totals.NoShowRate = &checkInRate, &noShowRate
+	}
+}
@@ -1,87 +1,276 @@
 package event
 
 import (
+	"errors"
 	"eventservice/src/internal/core"
+	"eventservice/src/pkg/ticket"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 )
 
 type Service struct {
-	repo core.EventRepository
+	repo     core.EventRepository
+	tickets  *ticket.Signer
+	cache    core.Cache
+	holdTTL  time.Duration
+	payments core.PaymentProvider
 }
 
-func NewService(repo core.EventRepository) Service {
-	return Service{repo: repo}
+func NewService(repo core.EventRepository, tickets *ticket.Signer, cache core.Cache, holdTTL time.Duration, payments core.PaymentProvider) Service {
+	return Service{repo: repo, tickets: tickets, cache: cache, holdTTL: holdTTL, payments: payments}
 }
 
 // CreateEvent creates a new event (for organizers)
 func (s *Service) CreateEvent(req *core.CreateEventRequest, organizerID int) (*core.Event, error) {
+	event, err := newEvent(req, organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateEvent(event)
+}
+
+// newEvent validates a create request and builds the event it describes
+func newEvent(req *core.CreateEventRequest, organizerID int) (*core.Event, error) {
 	// Validate required fields
 	if req.EventName == "" {
 		return nil, fmt.Errorf("event name is required")
 	}
-	if req.Place == "" {
-		return nil, fmt.Errorf("place is required")
+	if req.VenueID == 0 && strings.TrimSpace(req.Place) == "" {
+		return nil, fmt.Errorf("venue_id or place is required")
+	}
+
+	// With ticket tiers the event capacity is the sum of the tier capacities
+	tiers, tiersCapacity, err := core.ParseTicketTiers(req.Tiers)
+	if err != nil {
+		return nil, err
+	}
+	capacity := req.Capacity
+	if len(tiers) > 0 {
+		if capacity != 0 && capacity != tiersCapacity {
+			return nil, fmt.Errorf("capacity must equal the sum of ticket tier capacities (%d)", tiersCapacity)
+		}
+		capacity = tiersCapacity
 	}
-	if req.Capacity <= 0 {
+	if capacity <= 0 {
 		return nil, fmt.Errorf("capacity must be greater than 0")
 	}
 
-	// Parse and validate date
-	eventDate, err := time.Parse("2006-01-02", req.EventDate)
+	// Customers book one seat at a time unless the organizer allows groups
+	maxSeats := req.MaxSeatsPerBooking
+	if maxSeats == 0 {
+		maxSeats = core.DefaultMaxSeatsPerBooking
+	}
+	if maxSeats < 0 {
+		return nil, fmt.Errorf("max seats per booking must be greater than 0")
+	}
+
+	if req.Price < 0 {
+		return nil, fmt.Errorf("price cannot be negative")
+	}
+	currency, err := core.NormalizeCurrency(req.Currency)
 	if err != nil {
-		return nil, fmt.Errorf("invalid date format. Use YYYY-MM-DD")
+		return nil, err
+	}
+
+	// Customers may leave until the event starts unless the organizer sets a cutoff
+	var policy core.CancellationPolicy
+	if req.CancellationPolicy != nil {
+		policy = *req.CancellationPolicy
+	}
+	if err := policy.Normalize(); err != nil {
+		return nil, err
+	}
+
+	// New events are published straight away unless created as drafts
+	status := req.Status
+	if status == "" {
+		status = core.EventStatusPublished
+	}
+	if status != core.EventStatusDraft && status != core.EventStatusPublished {
+		return nil, fmt.Errorf("status must be either draft or published")
 	}
 
-	// Validate time formats
-	_, err = time.Parse("15:04", req.StartTime)
+	// Date and time fields are wall-clock times in the event's zone
+	loc, err := core.LoadTimeZone(req.TimeZone)
 	if err != nil {
-		return nil, fmt.Errorf("invalid start time format. Use HH:MM")
+		return nil, err
 	}
-	_, err = time.Parse("15:04", req.EndTime)
+
+	// Resolve the time range from either starts_at/ends_at or the date and time fields
+	startsAt, endsAt, err := core.ResolveTimeRange(req.StartsAt, req.EndsAt, req.EventDate, req.EndDate, req.StartTime, req.EndTime, loc)
 	if err != nil {
-		return nil, fmt.Errorf("invalid end time format. Use HH:MM")
+		return nil, err
 	}
 
-	// Check if the event date is in the future
-	if eventDate.Before(time.Now().Truncate(24 * time.Hour)) {
-		return nil, fmt.Errorf("event date must be in the future")
+	// Check if the event starts in the future
+	if startsAt.Before(time.Now()) {
+		return nil, fmt.Errorf("event must start in the future")
 	}
 
 	// Create event object
 	event := &core.Event{
-		EventName:   req.EventName,
-		OrganizerID: organizerID, // Use the organizerID from auth middleware
-		Place:       req.Place,
-		EventDate:   eventDate,
-		StartTime:   req.StartTime,
-		EndTime:     req.EndTime,
-		Capacity:    req.Capacity,
-		Filled:      0,
-		SeatsLeft:   req.Capacity,
+		EventName:          req.EventName,
+		Description:        strings.TrimSpace(req.Description),
+		OrganizerID:        organizerID, // Use the organizerID from auth middleware
+		VenueID:            req.VenueID,
+		Place:              req.Place,
+		StartsAt:           startsAt,
+		EndsAt:             endsAt,
+		TimeZone:           loc.String(),
+		Capacity:           capacity,
+		Filled:             0,
+		SeatsLeft:          capacity,
+		MaxSeatsPerBooking: maxSeats,
+		Price:              req.Price,
+		Currency:           currency,
+		CancellationPolicy: policy,
+		Status:             status,
+		Tiers:              tiers,
 	}
 
-	return s.repo.CreateEvent(event)
+	return event, nil
 }
 
 // JoinEvent allows a customer to join an event
 func (s *Service) JoinEvent(customerID int, eventID int) error {
-	return s.repo.JoinEvent(customerID, eventID)
+	request := &core.JoinEventRequest{EventID: eventID}
+	if err := request.Validate(); err != nil {
+		return err
+	}
+	return s.repo.JoinEvent(customerID, request)
 }
 
-// GetAllEventsForCustomers gets all available events for customers with filters
-func (s *Service) GetAllEventsForCustomers(filters *core.EventFilters) ([]core.EventResponse, error) {
+// GetAllEventsForCustomers gets one page of the events visible to customers
+func (s *Service) GetAllEventsForCustomers(filters *core.EventFilters) (*core.EventPage, error) {
+	if err := validatePublicStatusFilter(filters.Status); err != nil {
+		return nil, err
+	}
+	if err := validateEventFilters(filters); err != nil {
+		return nil, err
+	}
 	return s.repo.GetAllEventsForCustomers(filters)
 }
 
+// validateEventFilters rejects invalid listing filters and fills in the
+// sort, order and page size defaults
+func validateEventFilters(filters *core.EventFilters) error {
+	if filters.TimeZone != "" {
+		loc, err := core.LoadTimeZone(filters.TimeZone)
+		if err != nil {
+			return err
+		}
+		filters.TimeZone = loc.String()
+	}
+
+	var from, to time.Time
+	for _, field := range []struct {
+		name  string
+		value string
+		dest  *time.Time
+	}{
+		{"date", filters.Date, nil},
+		{"from", filters.From, &from},
+		{"to", filters.To, &to},
+	} {
+		if field.value == "" {
+			continue
+		}
+		date, err := time.Parse(core.DateLayout, field.value)
+		if err != nil {
+			return fmt.Errorf("invalid %s '%s'. Use YYYY-MM-DD", field.name, field.value)
+		}
+		if field.dest != nil {
+			*field.dest = date
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return fmt.Errorf("to must not be before from")
+	}
+
+	if filters.Upcoming && filters.Past {
+		return fmt.Errorf("upcoming and past cannot be combined")
+	}
+
+	if filters.MinCapacity < 0 || filters.MaxCapacity < 0 {
+		return fmt.Errorf("capacity filters must be positive")
+	}
+	if filters.MaxCapacity != 0 && filters.MinCapacity > filters.MaxCapacity {
+		return fmt.Errorf("min_capacity must not be greater than max_capacity")
+	}
+
+	if filters.RadiusKm != 0 && filters.Near == nil {
+		return fmt.Errorf("radius_km requires near")
+	}
+	if !(filters.RadiusKm >= 0 && filters.RadiusKm <= core.MaxRadiusKm) {
+		return fmt.Errorf("radius_km must be between 0 and %.0f", core.MaxRadiusKm)
+	}
+
+	// Near searches are sorted by distance and text searches by relevance,
+	// unless another sort is asked for
+	if filters.Sort == "" {
+		filters.Sort = core.EventSortDate
+		if filters.Near != nil {
+			filters.Sort = core.EventSortDistance
+		} else if filters.Query != "" {
+			filters.Sort = core.EventSortRelevance
+		}
+	}
+	if !core.IsValidEventSort(filters.Sort) {
+		return fmt.Errorf("invalid sort '%s'. Use date, name, capacity, seats_left, relevance or distance", filters.Sort)
+	}
+	if filters.Sort == core.EventSortRelevance && filters.Query == "" {
+		return fmt.Errorf("sort by relevance requires a search query")
+	}
+	if filters.Sort == core.EventSortDistance && filters.Near == nil {
+		return fmt.Errorf("sort by distance requires near")
+	}
+
+	if filters.Order == "" {
+		filters.Order = core.SortAsc
+		if filters.Sort == core.EventSortRelevance {
+			filters.Order = core.SortDesc
+		}
+	}
+	if filters.Order != core.SortAsc && filters.Order != core.SortDesc {
+		return fmt.Errorf("invalid order '%s'. Use asc or desc", filters.Order)
+	}
+
+	if filters.Limit == 0 {
+		filters.Limit = core.DefaultPageSize
+	}
+	if filters.Limit < 0 || filters.Limit > core.MaxPageSize {
+		return fmt.Errorf("limit must be between 1 and %d", core.MaxPageSize)
+	}
+
+	if filters.Cursor != "" {
+		cursor, err := core.DecodeEventCursor(filters.Cursor)
+		if err != nil {
+			return err
+		}
+		// A cursor only continues the listing it was issued for
+		if cursor.Sort != filters.Sort || cursor.Order != filters.Order {
+			return fmt.Errorf("cursor does not match the requested sort")
+		}
+		filters.After = cursor
+	}
+
+	return nil
+}
+
 // GetEventCustomers gets all customers who joined a specific event (for organizers)
 func (s *Service) GetEventCustomers(eventID, organizerID int) ([]core.CustomerBooking, error) {
 	return s.repo.GetEventCustomers(eventID, organizerID)
 }
 
-// GetOrganizerEvents gets all events created by an organizer
-func (s *Service) GetOrganizerEvents(organizerID int) ([]core.Event, error) {
-	return s.repo.GetOrganizerEvents(organizerID)
+// GetOrganizerEvents gets all events an organizer works on, optionally filtered by status
+func (s *Service) GetOrganizerEvents(organizerID int, status string) ([]core.Event, error) {
+	if status != "" && !core.IsValidEventStatus(status) {
+		return nil, fmt.Errorf("invalid status filter '%s'", status)
+	}
+	return s.repo.GetOrganizerEvents(organizerID, status)
 }
 
 // GetEventByID gets a specific event by ID
@@ -89,31 +278,200 @@ func (s *Service) GetEventByID(eventID int) (*core.Event, error) {
 	return s.repo.GetEventByID(eventID)
 }
 
-// GetAllEvents gets all events for customers with filters (renamed from GetAllEventsForCustomers)
-func (s *Service) GetAllEvents(filters *core.EventFilters) ([]core.EventResponse, error) {
-	return s.repo.GetAllEventsForCustomers(filters)
+// GetVisibleEvent gets an event for viewerID, or for an anonymous caller when
+// viewerID is 0. Drafts are only visible to the event's team.
+func (s *Service) GetVisibleEvent(eventID int, viewerID int) (*core.Event, error) {
+	event, err := s.repo.GetEventByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Status != core.EventStatusDraft {
+		return event, nil
+	}
+	if viewerID != 0 {
+		role, err := s.repo.GetEventRole(eventID, viewerID)
+		if err != nil {
+			return nil, err
+		}
+		if core.RoleHasPermission(role, core.PermissionView) {
+			return event, nil
+		}
+	}
+	return nil, fmt.Errorf("event not found")
+}
+
+// GetAllEvents gets one page of events for customers with filters (renamed from GetAllEventsForCustomers)
+func (s *Service) GetAllEvents(filters *core.EventFilters) (*core.EventPage, error) {
+	return s.GetAllEventsForCustomers(filters)
 }
 
 // GetEventsByOrganizer gets all events created by an organizer (alias for GetOrganizerEvents)
-func (s *Service) GetEventsByOrganizer(organizerID int) ([]core.Event, error) {
-	return s.repo.GetOrganizerEvents(organizerID)
+func (s *Service) GetEventsByOrganizer(organizerID int, status string) ([]core.Event, error) {
+	return s.GetOrganizerEvents(organizerID, status)
 }
 
-// UpdateEvent updates an existing event
+// UpdateEvent updates an existing event; cancelled and completed events are read-only
 func (s *Service) UpdateEvent(eventID int, request *core.UpdateEventRequest, organizerID int) (*core.Event, error) {
+	event, err := s.getTeamEvent(eventID, organizerID, core.PermissionEdit)
+	if err != nil {
+		return nil, err
+	}
+	if event.Status == core.EventStatusCancelled || event.Status == core.EventStatusCompleted {
+		return nil, fmt.Errorf("cannot update a %s event", event.Status)
+	}
+
+	if err := prepareUpdate(event, request); err != nil {
+		return nil, err
+	}
+
 	return s.repo.UpdateEvent(eventID, request, organizerID)
 }
 
+// prepareUpdate validates an update against the event and derives the
+// capacity of tiered events from their tiers
+func prepareUpdate(event *core.Event, request *core.UpdateEventRequest) error {
+	if request.MaxSeatsPerBooking < 0 {
+		return fmt.Errorf("max seats per booking must be greater than 0")
+	}
+	if request.Price != nil && *request.Price < 0 {
+		return fmt.Errorf("price cannot be negative")
+	}
+	if request.Currency != "" {
+		currency, err := core.NormalizeCurrency(request.Currency)
+		if err != nil {
+			return err
+		}
+		request.Currency = currency
+	}
+	if request.CancellationPolicy != nil {
+		if err := request.CancellationPolicy.Normalize(); err != nil {
+			return err
+		}
+	}
+	if len(request.Tiers) > 0 {
+		_, tiersCapacity, err := core.ParseTicketTiers(request.Tiers)
+		if err != nil {
+			return err
+		}
+		if request.Capacity != 0 && request.Capacity != tiersCapacity {
+			return fmt.Errorf("capacity must equal the sum of ticket tier capacities (%d)", tiersCapacity)
+		}
+		request.Capacity = tiersCapacity
+	} else if request.Capacity > 0 && len(event.Tiers) > 0 {
+		return fmt.Errorf("capacity of an event with ticket tiers is changed through its tiers")
+	}
+	return nil
+}
+
+// PublishEvent makes a draft event visible and open for booking
+func (s *Service) PublishEvent(eventID int, organizerID int) (*core.Event, error) {
+	return s.changeEventStatus(eventID, organizerID, core.EventStatusPublished)
+}
+
+// CancelEvent cancels a published event, keeping its bookings as cancelled by the organizer
+func (s *Service) CancelEvent(eventID int, organizerID int) (*core.Event, error) {
+	return s.changeEventStatus(eventID, organizerID, core.EventStatusCancelled)
+}
+
+// CompleteFinishedEvents marks every published event that has ended as completed
+func (s *Service) CompleteFinishedEvents() (int64, error) {
+	return s.repo.CompletePastEvents()
+}
+
+// changeEventStatus validates and applies a lifecycle transition requested by an organizer
+func (s *Service) changeEventStatus(eventID int, organizerID int, to string) (*core.Event, error) {
+	event, err := s.getTeamEvent(eventID, organizerID, core.PermissionEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if !core.CanTransitionEventStatus(event.Status, to) {
+		return nil, fmt.Errorf("cannot change event status from %s to %s", event.Status, to)
+	}
+
+	if err := s.repo.SetEventStatus(eventID, event.Status, to); err != nil {
+		return nil, err
+	}
+
+	// Refunds of a cancelled event are sent in the background; the retry
+	// worker picks up any that fail
+	if to == core.EventStatusCancelled {
+		go func() {
+			if _, err := s.ProcessPendingRefunds(); err != nil {
+				log.Printf("Failed to process refunds of event %d: %v", eventID, err)
+			}
+		}()
+	}
+
+	return s.repo.GetEventByID(eventID)
+}
+
+// getTeamEvent loads an event and checks that the organizer's team role on it grants permission
+func (s *Service) getTeamEvent(eventID int, organizerID int, permission string) (*core.Event, error) {
+	if err := s.requirePermission(eventID, organizerID, permission, "modify it"); err != nil {
+		return nil, err
+	}
+	event, err := s.repo.GetEventByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found or you don't have permission to modify it")
+	}
+	return event, nil
+}
+
+// requirePermission checks that the organizer's team role on an event grants
+// permission; action completes the error message
+func (s *Service) requirePermission(eventID int, organizerID int, permission string, action string) error {
+	role, err := s.repo.GetEventRole(eventID, organizerID)
+	if err != nil {
+		return err
+	}
+	if !core.RoleHasPermission(role, permission) {
+		return fmt.Errorf("event not found or you don't have permission to %s", action)
+	}
+	return nil
+}
+
+// validatePublicStatusFilter rejects statuses that are not visible to customers
+func validatePublicStatusFilter(status string) error {
+	if status == "" {
+		return nil
+	}
+	if !core.IsValidEventStatus(status) || status == core.EventStatusDraft {
+		return fmt.Errorf("invalid status filter '%s'", status)
+	}
+	return nil
+}
+
 // DeleteEvent deletes an event
 func (s *Service) DeleteEvent(eventID int, organizerID int) error {
 	return s.repo.DeleteEvent(eventID, organizerID)
 }
 
-// JoinEventWithRequest allows a customer to join an event using a request object
+// JoinEventWithRequest allows a customer to join an event using a request object.
+// When the event is full the customer is placed on its waitlist instead. Paid
+// events are only booked once the payment succeeds.
 func (s *Service) JoinEventWithRequest(userID int, request *core.JoinEventRequest) (*core.JoinEventResponse, error) {
-	// For this implementation, we need to get user details from context or make them optional
-	// Since we don't have access to email/username here, we'll use placeholder values
-	err := s.repo.JoinEvent(userID, request.EventID)
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Promo codes discount payments, so they always go through checkout
+	if request.PromoCode != "" {
+		return s.joinPaidEvent(userID, request)
+	}
+
+	err := s.repo.JoinEvent(userID, request)
+	if errors.Is(err, core.ErrPaymentRequired) {
+		return s.joinPaidEvent(userID, request)
+	}
+	if errors.Is(err, core.ErrEventFull) {
+		return s.joinWaitlist(userID, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.GetTicket(userID, request.EventID)
 	if err != nil {
 		return nil, err
 	}
@@ -121,12 +479,109 @@ func (s *Service) JoinEventWithRequest(userID int, request *core.JoinEventReques
 	return &core.JoinEventResponse{
 		Message: "Successfully joined event",
 		EventID: request.EventID,
+		Seats:   request.Seats,
+		Ticket:  ticket,
 	}, nil
 }
 
-// LeaveEvent allows a customer to leave an event
-func (s *Service) LeaveEvent(userID int, eventID int) error {
-	return s.repo.LeaveEvent(userID, eventID)
+// joinWaitlist queues a customer for a full event. Seats freed meanwhile are
+// booked at once, or on paid events held and paid for with the request's
+// payment method. A failed payment leaves the hold to be confirmed later.
+func (s *Service) joinWaitlist(userID int, request *core.JoinEventRequest) (*core.JoinEventResponse, error) {
+	entry, err := s.repo.JoinWaitlist(userID, request)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Position > 0 {
+		return &core.JoinEventResponse{
+			Message:          "Event is full, you have been added to the waitlist",
+			EventID:          request.EventID,
+			Seats:            request.Seats,
+			Waitlisted:       true,
+			WaitlistPosition: entry.Position,
+		}, nil
+	}
+
+	// Position 0 means seats were freed meanwhile and the customer got them
+	event, err := s.repo.GetEventByID(request.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Price > 0 {
+		holds, err := s.repo.GetUserSeatHolds(userID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range holds {
+			if holds[i].EventID != request.EventID {
+				continue
+			}
+			joined, err := s.confirmHold(userID, &holds[i], &core.ConfirmHoldRequest{
+				PaymentMethod: request.PaymentMethod,
+				PromoCode:     request.PromoCode,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("seats are held for you until %s, confirm the hold to book them: %v",
+					holds[i].ExpiresAt.UTC().Format(time.RFC3339), err)
+			}
+			return joined, nil
+		}
+	}
+
+	ticket, err := s.GetTicket(userID, request.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.JoinEventResponse{
+		Message: "Successfully joined event",
+		EventID: request.EventID,
+		Seats:   request.Seats,
+		Ticket:  ticket,
+	}, nil
+}
+
+// LeaveEvent releases some or, with seats 0, all of a customer's seats for an event
+func (s *Service) LeaveEvent(userID int, eventID int, seats int) (*core.LeaveEventResponse, error) {
+	if seats < 0 {
+		return nil, fmt.Errorf("seats must be greater than 0")
+	}
+
+	leaveResponse, err := s.repo.LeaveEvent(userID, eventID, seats)
+	if err != nil {
+		return nil, err
+	}
+
+	// The seats are released either way; a refund that fails now is retried
+	if refund := leaveResponse.Refund; refund != nil {
+		if processed, err := s.repo.ProcessRefund(refund.RefundID, s.sendRefund); err != nil {
+			log.Printf("Failed to process refund %d: %v", refund.RefundID, err)
+		} else {
+			leaveResponse.Refund = processed
+		}
+	}
+
+	leaveResponse.Message = "Successfully left event"
+	if leaveResponse.Seats > 0 {
+		leaveResponse.Message = fmt.Sprintf("Successfully released seats, %d still booked", leaveResponse.Seats)
+	}
+	if leaveResponse.LateCancellation != nil {
+		leaveResponse.Message += " (late cancellation)"
+	}
+	return leaveResponse, nil
+}
+
+// GetUserLateCancellations lists the late cancellations recorded against a customer
+func (s *Service) GetUserLateCancellations(customerID int) ([]core.LateCancellation, error) {
+	return s.repo.GetUserLateCancellations(customerID)
+}
+
+// GetEventLateCancellations lists the late cancellations of an event for its team
+func (s *Service) GetEventLateCancellations(eventID int, organizerID int) ([]core.LateCancellation, error) {
+	if err := s.requirePermission(eventID, organizerID, core.PermissionView, "view its cancellations"); err != nil {
+		return nil, err
+	}
+	return s.repo.GetEventLateCancellations(eventID, organizerID)
 }
 
 // GetUserBookings gets all events a user has booked
@@ -138,3 +593,18 @@ func (s *Service) GetUserBookings(userID int) ([]core.Event, error) {
 func (s *Service) GetEventParticipants(eventID, organizerID int) ([]core.CustomerBooking, error) {
 	return s.repo.GetEventCustomers(eventID, organizerID)
 }
+
+// LeaveWaitlist removes a customer from an event's waitlist
+func (s *Service) LeaveWaitlist(userID int, eventID int) error {
+	return s.repo.LeaveWaitlist(userID, eventID)
+}
+
+// GetUserWaitlist gets all waitlists a user is queued on with their positions
+func (s *Service) GetUserWaitlist(userID int) ([]core.WaitlistEntry, error) {
+	return s.repo.GetUserWaitlist(userID)
+}
+
+// GetEventWaitlist gets the waitlist of an event in queue order (for organizers)
+func (s *Service) GetEventWaitlist(eventID, organizerID int) ([]core.WaitlistEntry, error) {
+	return s.repo.GetEventWaitlist(eventID, organizerID)
+}
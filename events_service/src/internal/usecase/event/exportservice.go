@@ -0,0 +1,165 @@
+package event
+
+import (
+	"eventservice/src/internal/core"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExportEventParticipants builds the participant table of one of an organizer's
+// events. columns is a comma separated list of core.ExportColumn names; times
+// are written in loc.
+func (s *Service) ExportEventParticipants(eventID int, organizerID int, columns string, loc *time.Location) (*core.ParticipantExport, error) {
+	parsed, err := parseExportColumns(columns, core.DefaultExportColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.repo.GetEventCustomers(eventID, organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildParticipantExport(bookings, parsed, loc), nil
+}
+
+// ExportOrganizerParticipants builds one participant table across all of an
+// organizer's events
+func (s *Service) ExportOrganizerParticipants(organizerID int, columns string, loc *time.Location) (*core.ParticipantExport, error) {
+	defaults := append([]string{core.ExportColumnEventID, core.ExportColumnEventName}, core.DefaultExportColumns...)
+	parsed, err := parseExportColumns(columns, defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.repo.GetOrganizerCustomers(organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildParticipantExport(bookings, parsed, loc), nil
+}
+
+// parseExportColumns splits a comma separated column list, falling back to defaults when it is empty
+func parseExportColumns(columns string, defaults []string) ([]string, error) {
+	if strings.TrimSpace(columns) == "" {
+		return defaults, nil
+	}
+
+	var parsed []string
+	seen := make(map[string]bool)
+	for _, column := range strings.Split(columns, ",") {
+		column = strings.TrimSpace(column)
+		switch {
+		case column == "":
+			continue
+		case strings.HasPrefix(column, core.ExportColumnAnswerPrefix):
+			question := strings.TrimSpace(strings.TrimPrefix(column, core.ExportColumnAnswerPrefix))
+			if question == "" {
+				return nil, fmt.Errorf("column '%s' needs a question", column)
+			}
+			column = core.ExportColumnAnswerPrefix + question
+		case !exportColumns[column]:
+			return nil, fmt.Errorf("invalid column '%s'", column)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("column '%s' is listed twice", column)
+		}
+		seen[column] = true
+		parsed = append(parsed, column)
+	}
+	return parsed, nil
+}
+
+// exportColumns holds the fixed export columns
+var exportColumns = map[string]bool{
+	core.ExportColumnEventID: true, core.ExportColumnEventName: true, core.ExportColumnName: true,
+	core.ExportColumnEmail: true, core.ExportColumnTier: true, core.ExportColumnSeats: true,
+	core.ExportColumnAttendeeNames: true, core.ExportColumnStatus: true, core.ExportColumnBookedAt: true,
+	core.ExportColumnCheckedIn: true, core.ExportColumnCheckedInAt: true, core.ExportColumnAnswers: true,
+}
+
+// buildParticipantExport lays out bookings as rows of the given columns. The
+// answers column becomes one column per question, sorted by question.
+func buildParticipantExport(bookings []core.CustomerBooking, columns []string, loc *time.Location) *core.ParticipantExport {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	// Expand answers into the questions that were answered and not picked individually
+	var expanded []string
+	for _, column := range columns {
+		if column != core.ExportColumnAnswers {
+			expanded = append(expanded, column)
+			continue
+		}
+		questions := make(map[string]bool)
+		for _, booking := range bookings {
+			for question := range booking.Answers {
+				if !slices.Contains(columns, core.ExportColumnAnswerPrefix+question) {
+					questions[question] = true
+				}
+			}
+		}
+		var sorted []string
+		for question := range questions {
+			sorted = append(sorted, question)
+		}
+		sort.Strings(sorted)
+		for _, question := range sorted {
+			expanded = append(expanded, core.ExportColumnAnswerPrefix+question)
+		}
+	}
+
+	export := &core.ParticipantExport{
+		Header: make([]string, len(expanded)),
+		Rows:   make([][]interface{}, 0, len(bookings)),
+	}
+	for i, column := range expanded {
+		export.Header[i] = strings.TrimPrefix(column, core.ExportColumnAnswerPrefix)
+	}
+
+	formatTime := func(t time.Time) string {
+		return t.In(loc).Format(time.RFC3339)
+	}
+	for _, booking := range bookings {
+		row := make([]interface{}, len(expanded))
+		for i, column := range expanded {
+			switch column {
+			case core.ExportColumnEventID:
+				row[i] = booking.EventID
+			case core.ExportColumnEventName:
+				row[i] = booking.EventName
+			case core.ExportColumnName:
+				row[i] = booking.CUsername
+			case core.ExportColumnEmail:
+				row[i] = booking.CEmail
+			case core.ExportColumnTier:
+				row[i] = booking.Tier
+			case core.ExportColumnSeats:
+				row[i] = booking.Seats
+			case core.ExportColumnAttendeeNames:
+				row[i] = strings.Join(booking.AttendeeNames, "; ")
+			case core.ExportColumnStatus:
+				row[i] = booking.Status
+			case core.ExportColumnBookedAt:
+				row[i] = formatTime(booking.BookedAt)
+			case core.ExportColumnCheckedIn:
+				row[i] = booking.CheckedIn
+			case core.ExportColumnCheckedInAt:
+				row[i] = ""
+				if booking.CheckedInAt != nil {
+					row[i] = formatTime(*booking.CheckedInAt)
+				}
+			default:
+				row[i] = booking.Answers[strings.TrimPrefix(column, core.ExportColumnAnswerPrefix)]
+			}
+		}
+		export.Rows = append(export.Rows, row)
+	}
+
+	return export
+}
@@ -0,0 +1,94 @@
+package event
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"eventservice/src/internal/core"
+	"fmt"
+)
+
+// feedTokenBytes is the amount of randomness in a calendar feed token
+const feedTokenBytes = 32
+
+// CreateCalendarFeed creates a user's calendar feed and returns its token,
+// which is not shown again
+func (s *Service) CreateCalendarFeed(userID int, kind string) (*core.CalendarFeed, error) {
+	token, tokenHash, err := newFeedToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := s.repo.CreateCalendarFeed(userID, kind, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	feed.Token = token
+	return feed, nil
+}
+
+// RotateCalendarFeed gives a user's calendar feed a new token; subscriptions
+// to the old URL stop updating
+func (s *Service) RotateCalendarFeed(userID int, kind string) (*core.CalendarFeed, error) {
+	token, tokenHash, err := newFeedToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed, err := s.repo.RotateCalendarFeed(userID, kind, tokenHash)
+	if err != nil {
+		return nil, err
+	}
+	feed.Token = token
+	return feed, nil
+}
+
+// RevokeCalendarFeed deletes a user's calendar feed
+func (s *Service) RevokeCalendarFeed(userID int, kind string) error {
+	return s.repo.DeleteCalendarFeed(userID, kind)
+}
+
+// GetCalendarFeed gets a user's calendar feed, without its token
+func (s *Service) GetCalendarFeed(userID int, kind string) (*core.CalendarFeed, error) {
+	return s.repo.GetCalendarFeed(userID, kind)
+}
+
+// GetCalendarFeedEvents resolves a feed token to its feed and the events it
+// currently lists: a customer's bookings or an organizer's events
+func (s *Service) GetCalendarFeedEvents(token string) (*core.CalendarFeed, []core.Event, error) {
+	feed, err := s.repo.GetCalendarFeedByToken(hashFeedToken(token))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var events []core.Event
+	switch feed.Kind {
+	case core.CalendarFeedBookings:
+		events, err = s.repo.GetUserBookings(feed.UserID)
+	case core.CalendarFeedOrganizer:
+		events, err = s.repo.GetOrganizerEvents(feed.UserID, "")
+	default:
+		return nil, nil, fmt.Errorf("unknown calendar feed kind '%s'", feed.Kind)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return feed, events, nil
+}
+
+// newFeedToken returns a random feed token and the hash it is stored as
+func newFeedToken() (token string, tokenHash string, err error) {
+	buf := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate feed token: %v", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashFeedToken(token), nil
+}
+
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
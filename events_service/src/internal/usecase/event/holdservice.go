@@ -0,0 +1,87 @@
+package event
+
+import (
+	"eventservice/src/internal/core"
+	"fmt"
+	"time"
+)
+
+// HoldSeats reserves seats of an event for a customer while they check out.
+// The hold lapses after the configured TTL unless it is confirmed.
+func (s *Service) HoldSeats(customerID int, request *core.JoinEventRequest) (*core.SeatHold, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.CreateSeatHold(customerID, request, s.holdTTL)
+}
+
+// GetSeatHolds lists a customer's active holds
+func (s *Service) GetSeatHolds(customerID int) ([]core.SeatHold, error) {
+	return s.repo.GetUserSeatHolds(customerID)
+}
+
+// ConfirmSeatHold books the seats of a customer's hold and returns the ticket.
+// Holds on paid events are charged to the request's payment method first,
+// less any promo code; a declined payment leaves the hold in place so another
+// method can be tried.
+func (s *Service) ConfirmSeatHold(customerID int, holdID int, request *core.ConfirmHoldRequest) (*core.JoinEventResponse, error) {
+	hold, err := s.repo.GetSeatHold(customerID, holdID)
+	if err != nil {
+		return nil, err
+	}
+	return s.confirmHold(customerID, hold, request)
+}
+
+// confirmHold pays for a hold if its event has a price and books its seats
+func (s *Service) confirmHold(customerID int, hold *core.SeatHold, request *core.ConfirmHoldRequest) (*core.JoinEventResponse, error) {
+	payment, redemption, err := s.payForHold(customerID, hold, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.ConfirmSeatHold(customerID, hold.HoldID, payment, redemption); err != nil {
+		// The hold lapsed, the code ran out or the booking failed after the charge went through
+		if payment != nil && payment.PaymentID != "" {
+			s.refundUnbookedPayment(customerID, hold.EventID, payment)
+		}
+		return nil, err
+	}
+
+	ticket, err := s.GetTicket(customerID, hold.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.JoinEventResponse{
+		Message: "Successfully joined event",
+		EventID: hold.EventID,
+		Seats:   hold.Seats,
+		Ticket:  ticket,
+		Payment: payment,
+	}, nil
+}
+
+// ReleaseSeatHold gives up a customer's hold before it expires
+func (s *Service) ReleaseSeatHold(customerID int, holdID int) error {
+	return s.repo.ReleaseSeatHold(customerID, holdID)
+}
+
+// ReleaseExpiredSeatHolds frees the seats of every lapsed hold
+func (s *Service) ReleaseExpiredSeatHolds() (int64, error) {
+	return s.repo.ReleaseExpiredSeatHolds()
+}
+
+// ParseSeatHoldTTL reads the configured hold lifetime, e.g. "10m"; empty means the default
+func ParseSeatHoldTTL(value string) (time.Duration, error) {
+	if value == "" {
+		return core.DefaultSeatHoldTTL, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid seat hold TTL '%s': %v", value, err)
+	}
+	if ttl < core.MinSeatHoldTTL || ttl > core.MaxSeatHoldTTL {
+		return 0, fmt.Errorf("seat hold TTL must be between %v and %v", core.MinSeatHoldTTL, core.MaxSeatHoldTTL)
+	}
+	return ttl, nil
+}
@@ -0,0 +1,217 @@
+package event
+
+import (
+	"encoding/csv"
+	"errors"
+	"eventservice/src/internal/core"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// importRow is a parsed CSV row: the event it describes, or why it is invalid
+type importRow struct {
+	line      int
+	eventName string
+	event     *core.Event
+	err       error
+}
+
+// ImportEvents creates an organizer's events from a CSV whose header names
+// core.ImportColumns. Every row goes through the same validation as
+// CreateEvent. In all_or_nothing mode a single invalid row cancels the import;
+// in valid_only mode the valid rows are created. A dry run reports what would
+// happen without creating anything.
+func (s *Service) ImportEvents(r io.Reader, organizerID int, mode string, dryRun bool) (*core.ImportResponse, error) {
+	if mode == "" {
+		mode = core.ImportModeAllOrNothing
+	}
+	if mode != core.ImportModeAllOrNothing && mode != core.ImportModeValidOnly {
+		return nil, fmt.Errorf("invalid mode '%s'. Use %s or %s", mode, core.ImportModeAllOrNothing, core.ImportModeValidOnly)
+	}
+
+	rows, err := parseImportCSV(r, organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &core.ImportResponse{Mode: mode, DryRun: dryRun, Total: len(rows), Rows: make([]core.ImportRowResult, len(rows))}
+	var events []core.Event
+	var eventRows []int
+	for i, row := range rows {
+		result.Rows[i] = core.ImportRowResult{Row: row.line, EventName: row.eventName}
+		if row.err != nil {
+			result.Rows[i].Status = core.ImportRowInvalid
+			result.Rows[i].Error = row.err.Error()
+			result.Invalid++
+			continue
+		}
+		events = append(events, *row.event)
+		eventRows = append(eventRows, i)
+	}
+
+	if len(events) > 0 {
+		imported, committed, err := s.repo.ImportEvents(events, func(failed int) bool {
+			invalid := result.Invalid + failed
+			return !dryRun && invalid < result.Total && (mode == core.ImportModeValidOnly || invalid == 0)
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.Committed = committed
+
+		for i, outcome := range imported {
+			rowResult := &result.Rows[eventRows[i]]
+			if outcome.Err != nil {
+				rowResult.Status = core.ImportRowInvalid
+				rowResult.Error = outcome.Err.Error()
+				var venueErr *core.VenueUnavailableError
+				if errors.As(outcome.Err, &venueErr) {
+					rowResult.ConflictingEventID = venueErr.ConflictingEventID
+				}
+				result.Invalid++
+				continue
+			}
+			if committed {
+				rowResult.Status = core.ImportRowCreated
+				rowResult.EventID = outcome.EventID
+				result.Created++
+			} else {
+				rowResult.Status = core.ImportRowValid
+			}
+		}
+	}
+	result.Valid = result.Total - result.Invalid
+
+	return result, nil
+}
+
+// parseImportCSV reads the header and rows of an event import, validating each
+// row into an event. Only a malformed file is an error; bad rows are reported
+// in their importRow.
+func parseImportCSV(r io.Reader, organizerID int) ([]importRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\uFEFF") // Spreadsheet apps may start UTF-8 files with a BOM
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(core.ImportColumns, name) {
+			return nil, fmt.Errorf("unknown column '%s'. Columns are: %s", name, strings.Join(core.ImportColumns, ", "))
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("column '%s' is listed twice", name)
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"event_name", "capacity"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("column '%s' is required", required)
+		}
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			rows = append(rows, importRow{line: line, err: fmt.Errorf("row has %d fields, expected %d", len(record), len(header))})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %v", err)
+		}
+
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(rows) == core.MaxImportRows {
+			return nil, fmt.Errorf("at most %d events can be imported at once", core.MaxImportRows)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		row := importRow{line: line, eventName: field("event_name")}
+		request, err := importRequest(field)
+		if err == nil {
+			row.event, err = newEvent(request, organizerID)
+		}
+		row.err = err
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("CSV has no events")
+	}
+	return rows, nil
+}
+
+// importRequest builds the create request of an import row
+func importRequest(field func(name string) string) (*core.CreateEventRequest, error) {
+	request := &core.CreateEventRequest{
+		EventName:   field("event_name"),
+		Description: field("description"),
+		Place:       field("place"),
+		StartsAt:    field("starts_at"),
+		EndsAt:      field("ends_at"),
+		EventDate:   field("event_date"),
+		EndDate:     field("end_date"),
+		StartTime:   field("start_time"),
+		EndTime:     field("end_time"),
+		TimeZone:    field("time_zone"),
+		Status:      field("status"),
+	}
+
+	ints := []struct {
+		name   string
+		target *int
+	}{
+		{"venue_id", &request.VenueID},
+		{"capacity", &request.Capacity},
+		{"max_seats_per_booking", &request.MaxSeatsPerBooking},
+	}
+	for _, param := range ints {
+		value := field(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s '%s'", param.name, value)
+		}
+		*param.target = parsed
+	}
+
+	return request, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
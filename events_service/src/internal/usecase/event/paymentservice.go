@@ -0,0 +1,173 @@
+package event
+
+import (
+	"errors"
+	"eventservice/src/internal/core"
+	"fmt"
+	"log"
+)
+
+// refundBatchSize caps the refunds sent per ProcessPendingRefunds call
+const refundBatchSize = 100
+
+// joinPaidEvent books a paid event by holding the seats, charging for them and
+// confirming the hold. The hold is released when the payment fails. Customers
+// are queued on the waitlist when the event is full.
+func (s *Service) joinPaidEvent(customerID int, request *core.JoinEventRequest) (*core.JoinEventResponse, error) {
+	hold, err := s.repo.CreateSeatHold(customerID, request, s.holdTTL)
+	if errors.Is(err, core.ErrEventFull) {
+		return s.joinWaitlist(customerID, request)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	joined, err := s.confirmHold(customerID, hold, &core.ConfirmHoldRequest{
+		PaymentMethod: request.PaymentMethod,
+		PromoCode:     request.PromoCode,
+	})
+	if err != nil {
+		if releaseErr := s.repo.ReleaseSeatHold(customerID, hold.HoldID); releaseErr != nil {
+			log.Printf("Failed to release seat hold %d: %v", hold.HoldID, releaseErr)
+		}
+		return nil, err
+	}
+
+	return joined, nil
+}
+
+// payForHold charges for the seats of a hold at the event's current price, less
+// the discount of the request's promo code. It returns the redemption to record
+// with the booking, and nil for free events. Nothing is charged when the code
+// covers the whole price.
+func (s *Service) payForHold(customerID int, hold *core.SeatHold, request *core.ConfirmHoldRequest) (*core.BookingPayment, *core.PromoRedemption, error) {
+	event, err := s.repo.GetEventByID(hold.EventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if event.Price == 0 {
+		if request.PromoCode != "" {
+			return nil, nil, fmt.Errorf("promo codes only apply to paid events")
+		}
+		return nil, nil, nil
+	}
+
+	total := event.Price * int64(hold.Seats)
+	var redemption *core.PromoRedemption
+	if request.PromoCode != "" {
+		promo, quote, err := s.quotePromoCode(customerID, event, hold.Seats, request.PromoCode)
+		if err != nil {
+			return nil, nil, err
+		}
+		total = quote.Total
+		redemption = &core.PromoRedemption{
+			PromoID:    promo.PromoID,
+			EventID:    event.EventID,
+			CID:        customerID,
+			Seats:      hold.Seats,
+			Subtotal:   quote.Subtotal,
+			Discount:   quote.Discount,
+			AmountPaid: quote.Total,
+			Currency:   quote.Currency,
+		}
+	}
+
+	// Seats are refunded at what they cost after the discount
+	payment := &core.BookingPayment{
+		Status:    core.PaymentStatusPaid,
+		UnitPrice: total / int64(hold.Seats),
+		Amount:    total,
+		Currency:  event.Currency,
+	}
+	if total == 0 {
+		return payment, redemption, nil
+	}
+	if request.PaymentMethod == "" {
+		return nil, nil, fmt.Errorf("payment_method is required for paid events")
+	}
+
+	// Retrying a hold with the same method and code must not charge twice
+	idempotencyKey := fmt.Sprintf("hold-%d-%s", hold.HoldID, request.PaymentMethod)
+	if redemption != nil {
+		idempotencyKey += "-" + request.PromoCode
+	}
+	intent, err := s.payments.CreatePaymentIntent(&core.PaymentIntentRequest{
+		Amount:         total,
+		Currency:       event.Currency,
+		CustomerID:     customerID,
+		EventID:        event.EventID,
+		PaymentMethod:  request.PaymentMethod,
+		Description:    fmt.Sprintf("%d x %s", hold.Seats, event.EventName),
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("payment failed: %v", err)
+	}
+	if intent.Status != core.PaymentIntentSucceeded {
+		return nil, nil, fmt.Errorf("payment declined: %s", intent.FailureReason)
+	}
+
+	payment.PaymentID = intent.ID
+	payment.Amount = intent.Amount
+	payment.Currency = intent.Currency
+	if redemption != nil {
+		redemption.PaymentID = intent.ID
+	}
+	return payment, redemption, nil
+}
+
+// refundUnbookedPayment returns a charge that did not end in a booking
+func (s *Service) refundUnbookedPayment(customerID int, eventID int, payment *core.BookingPayment) {
+	refund, err := s.repo.QueueRefund(&core.Refund{
+		EventID:   eventID,
+		CID:       customerID,
+		PaymentID: payment.PaymentID,
+		Amount:    payment.Amount,
+		Currency:  payment.Currency,
+		Reason:    core.RefundReasonCustomerCancelled,
+	})
+	if err != nil {
+		log.Printf("Failed to queue refund of payment %s: %v", payment.PaymentID, err)
+		return
+	}
+	if _, err := s.repo.ProcessRefund(refund.RefundID, s.sendRefund); err != nil {
+		log.Printf("Failed to process refund %d: %v", refund.RefundID, err)
+	}
+}
+
+// sendRefund asks the payment provider to pay a refund back. The refund ID
+// keys the request, so retries never refund twice.
+func (s *Service) sendRefund(refund *core.Refund) (*core.PaymentRefund, error) {
+	return s.payments.Refund(&core.RefundRequest{
+		PaymentID:      refund.PaymentID,
+		Amount:         refund.Amount,
+		IdempotencyKey: fmt.Sprintf("refund-%d", refund.RefundID),
+	})
+}
+
+// ProcessPendingRefunds sends the refunds waiting in the queue and returns
+// how many went through
+func (s *Service) ProcessPendingRefunds() (int, error) {
+	refundIDs, err := s.repo.GetPendingRefundIDs(refundBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	succeeded := 0
+	for _, refundID := range refundIDs {
+		refund, err := s.repo.ProcessRefund(refundID, s.sendRefund)
+		if err != nil {
+			return succeeded, err
+		}
+		if refund.Status == core.RefundStatusSucceeded {
+			succeeded++
+		}
+	}
+
+	return succeeded, nil
+}
+
+// GetUserRefunds lists the refunds of a customer's payments
+func (s *Service) GetUserRefunds(customerID int) ([]core.Refund, error) {
+	return s.repo.GetUserRefunds(customerID)
+}
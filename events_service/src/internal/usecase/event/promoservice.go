@@ -0,0 +1,150 @@
+package event
+
+import (
+	"eventservice/src/internal/core"
+	"fmt"
+	"time"
+)
+
+// CreatePromoCode validates and stores an organizer's promo code. Codes tied to
+// an event need edit permission on it; codes without one cover every event
+// the organizer created.
+func (s *Service) CreatePromoCode(organizerID int, request *core.CreatePromoCodeRequest) (*core.PromoCode, error) {
+	code, err := core.NormalizePromoCode(request.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	promo := &core.PromoCode{
+		OrganizerID:        organizerID,
+		EventID:            request.EventID,
+		Code:               code,
+		Kind:               request.Kind,
+		Amount:             request.Amount,
+		MaxUses:            request.MaxUses,
+		MaxUsesPerCustomer: request.MaxUsesPerCustomer,
+	}
+
+	switch promo.Kind {
+	case core.PromoKindPercentage:
+		if promo.Amount < 1 || promo.Amount > 100 {
+			return nil, fmt.Errorf("percentage discounts must be between 1 and 100")
+		}
+		if request.Currency != "" {
+			return nil, fmt.Errorf("currency only applies to fixed discounts")
+		}
+	case core.PromoKindFixed:
+		if promo.Amount < 1 {
+			return nil, fmt.Errorf("fixed discounts must be positive")
+		}
+		if promo.Currency, err = core.NormalizeCurrency(request.Currency); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid kind '%s'. Use %s or %s", request.Kind, core.PromoKindPercentage, core.PromoKindFixed)
+	}
+
+	if promo.MaxUses < 0 || promo.MaxUsesPerCustomer < 0 {
+		return nil, fmt.Errorf("usage limits cannot be negative")
+	}
+	if promo.MaxUses > 0 && promo.MaxUsesPerCustomer > promo.MaxUses {
+		return nil, fmt.Errorf("max_uses_per_customer cannot exceed max_uses")
+	}
+
+	if request.ValidFrom != "" {
+		validFrom, err := time.Parse(time.RFC3339, request.ValidFrom)
+		if err != nil {
+			return nil, fmt.Errorf("invalid valid_from. Use RFC 3339, e.g. 2025-01-02T15:04:05Z")
+		}
+		promo.ValidFrom = &validFrom
+	}
+	if request.ValidUntil != "" {
+		validUntil, err := time.Parse(time.RFC3339, request.ValidUntil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid valid_until. Use RFC 3339, e.g. 2025-01-02T15:04:05Z")
+		}
+		promo.ValidUntil = &validUntil
+	}
+	if promo.ValidFrom != nil && promo.ValidUntil != nil && !promo.ValidUntil.After(*promo.ValidFrom) {
+		return nil, fmt.Errorf("valid_until must be after valid_from")
+	}
+
+	if promo.EventID != 0 {
+		if err := s.requirePermission(promo.EventID, organizerID, core.PermissionEdit, "create promo codes for it"); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.repo.CreatePromoCode(promo)
+}
+
+// GetPromoCodes lists the promo codes an organizer created with their uses
+func (s *Service) GetPromoCodes(organizerID int) ([]core.PromoCode, error) {
+	return s.repo.GetOrganizerPromoCodes(organizerID)
+}
+
+// DeactivatePromoCode stops a promo code from being applied to new bookings
+func (s *Service) DeactivatePromoCode(organizerID int, promoID int) error {
+	return s.repo.DeactivatePromoCode(organizerID, promoID)
+}
+
+// GetPromoRedemptions lists the bookings a promo code was applied to
+func (s *Service) GetPromoRedemptions(organizerID int, promoID int) ([]core.PromoRedemption, error) {
+	return s.repo.GetPromoRedemptions(organizerID, promoID)
+}
+
+// ValidatePromoCode prices a booking of seats with a promo code, so customers
+// can check a code before booking. Limits are checked again when booking.
+func (s *Service) ValidatePromoCode(customerID int, eventID int, code string, seats int) (*core.PromoQuote, error) {
+	event, err := s.repo.GetEventByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Status != core.EventStatusPublished {
+		return nil, fmt.Errorf("event is not open for booking")
+	}
+	if seats == 0 {
+		seats = 1
+	}
+	if seats < 1 || seats > event.MaxSeatsPerBooking {
+		return nil, fmt.Errorf("seats must be between 1 and %d for this event", event.MaxSeatsPerBooking)
+	}
+
+	_, quote, err := s.quotePromoCode(customerID, event, seats, code)
+	return quote, err
+}
+
+// quotePromoCode looks up the code a customer entered for an event and prices
+// a booking of seats with it
+func (s *Service) quotePromoCode(customerID int, event *core.Event, seats int, code string) (*core.PromoCode, *core.PromoQuote, error) {
+	if event.Price == 0 {
+		return nil, nil, fmt.Errorf("promo codes only apply to paid events")
+	}
+	code, err := core.NormalizePromoCode(code)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	promo, customerUses, err := s.repo.GetPromoCodeForEvent(code, event.EventID, customerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := promo.CheckUsable(event, time.Now()); err != nil {
+		return nil, nil, err
+	}
+	if err := promo.CheckUses(customerUses); err != nil {
+		return nil, nil, err
+	}
+
+	subtotal := event.Price * int64(seats)
+	discount := promo.Discount(subtotal)
+	return promo, &core.PromoQuote{
+		Code:     promo.Code,
+		EventID:  event.EventID,
+		Seats:    seats,
+		Subtotal: subtotal,
+		Discount: discount,
+		Total:    subtotal - discount,
+		Currency: event.Currency,
+	}, nil
+}
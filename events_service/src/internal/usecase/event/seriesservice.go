@@ -0,0 +1,129 @@
+package event
+
+import (
+	"eventservice/src/internal/core"
+	"eventservice/src/pkg/recurrence"
+	"fmt"
+	"time"
+)
+
+// CreateSeries creates a recurring series, one event per occurrence (for organizers)
+func (s *Service) CreateSeries(req *core.CreateSeriesRequest, organizerID int) (*core.SeriesResponse, error) {
+	template, err := newEvent(&req.CreateEventRequest, organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule, err := recurrence.Parse(req.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	var exdates []time.Time
+	for _, value := range req.ExDates {
+		exdate, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exdate '%s'. Use YYYY-MM-DD", value)
+		}
+		exdates = append(exdates, exdate)
+	}
+
+	// Dates are expanded in the event's zone so occurrences keep their local
+	// times across daylight saving changes
+	start := template.StartsAt
+	dates, err := rule.Occurrences(start, exdates)
+	if err != nil {
+		return nil, err
+	}
+	if len(dates) == 0 {
+		return nil, fmt.Errorf("recurrence rule does not produce any occurrence")
+	}
+
+	// Each occurrence keeps the template's wall-clock times and length
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	occurrences := make([]core.Event, len(dates))
+	for i, date := range dates {
+		days := int(date.Sub(first).Hours() / 24)
+		occurrence := *template
+		occurrence.StartsAt = start.AddDate(0, 0, days)
+		occurrence.EndsAt = template.EndsAt.AddDate(0, 0, days)
+		occurrence.Tiers = append([]core.TicketTier(nil), template.Tiers...)
+		occurrences[i] = occurrence
+	}
+
+	series := &core.EventSeries{
+		OrganizerID: organizerID,
+		RRule:       req.RRule,
+		ExDates:     exdates,
+	}
+
+	return s.repo.CreateSeries(series, occurrences)
+}
+
+// UpdateSeriesEvents applies an update to this and following, or all,
+// occurrences of the series the event belongs to
+func (s *Service) UpdateSeriesEvents(eventID int, request *core.UpdateEventRequest, organizerID int, scope string) ([]core.Event, error) {
+	event, err := s.getTeamEvent(eventID, organizerID, core.PermissionEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Moving a whole range to one date makes no sense
+	if request.EventDate != "" || request.EndDate != "" || request.StartsAt != "" || request.EndsAt != "" {
+		return nil, fmt.Errorf("event dates can only be changed for a single occurrence, use start_time and end_time")
+	}
+
+	if err := prepareUpdate(event, request); err != nil {
+		return nil, err
+	}
+
+	eventIDs, err := s.seriesScopeEventIDs(event, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.UpdateEvents(eventIDs, request, organizerID)
+}
+
+// DeleteSeriesEvents deletes this and following, or all, occurrences of the
+// series the event belongs to
+func (s *Service) DeleteSeriesEvents(eventID int, organizerID int, scope string) error {
+	event, err := s.getTeamEvent(eventID, organizerID, core.PermissionManage)
+	if err != nil {
+		return err
+	}
+
+	eventIDs, err := s.seriesScopeEventIDs(event, scope)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteEvents(eventIDs, organizerID)
+}
+
+// seriesScopeEventIDs resolves an edit scope to the editable occurrences it covers
+func (s *Service) seriesScopeEventIDs(event *core.Event, scope string) ([]int, error) {
+	if event.SeriesID == 0 {
+		return nil, fmt.Errorf("event is not part of a recurring series")
+	}
+
+	var from time.Time
+	switch scope {
+	case core.SeriesScopeFollowing:
+		from = event.StartsAt
+	case core.SeriesScopeAll:
+		// Every occurrence
+	default:
+		return nil, fmt.Errorf("invalid scope '%s'. Use this, following or all", scope)
+	}
+
+	eventIDs, err := s.repo.GetSeriesEventIDs(event.SeriesID, from)
+	if err != nil {
+		return nil, err
+	}
+	if len(eventIDs) == 0 {
+		return nil, fmt.Errorf("no editable occurrences in this scope")
+	}
+
+	return eventIDs, nil
+}
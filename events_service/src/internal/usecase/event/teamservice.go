@@ -0,0 +1,61 @@
+package event
+
+import (
+	"eventservice/src/internal/core"
+	"fmt"
+	"strings"
+)
+
+// GetEventTeam lists the team of an event the organizer works on
+func (s *Service) GetEventTeam(eventID int, organizerID int) ([]core.TeamMember, error) {
+	if err := s.requirePermission(eventID, organizerID, core.PermissionView, "view its team"); err != nil {
+		return nil, err
+	}
+	return s.repo.GetEventTeam(eventID)
+}
+
+// AddTeamMember invites another organizer to an event's team (owners only)
+func (s *Service) AddTeamMember(eventID int, request *core.AddTeamMemberRequest, organizerID int) (*core.TeamMember, error) {
+	if err := validateTeamRole(request.Role); err != nil {
+		return nil, err
+	}
+	if request.OrganizerID == 0 && strings.TrimSpace(request.Email) == "" {
+		return nil, fmt.Errorf("organizer_id or email is required")
+	}
+	if err := s.requirePermission(eventID, organizerID, core.PermissionManage, "manage its team"); err != nil {
+		return nil, err
+	}
+	return s.repo.AddTeamMember(eventID, request, organizerID)
+}
+
+// UpdateTeamMember changes a team member's role (owners only). The event's
+// creator always stays an owner.
+func (s *Service) UpdateTeamMember(eventID int, memberID int, request *core.UpdateTeamMemberRequest, organizerID int) error {
+	if err := validateTeamRole(request.Role); err != nil {
+		return err
+	}
+	if err := s.requirePermission(eventID, organizerID, core.PermissionManage, "manage its team"); err != nil {
+		return err
+	}
+	return s.repo.UpdateTeamMemberRole(eventID, memberID, request.Role)
+}
+
+// RemoveTeamMember takes an organizer off an event's team. Owners may remove
+// anyone but the event's creator; other members may only leave.
+func (s *Service) RemoveTeamMember(eventID int, memberID int, organizerID int) error {
+	if memberID != organizerID {
+		if err := s.requirePermission(eventID, organizerID, core.PermissionManage, "manage its team"); err != nil {
+			return err
+		}
+	}
+	return s.repo.RemoveTeamMember(eventID, memberID)
+}
+
+// validateTeamRole rejects unknown team roles
+func validateTeamRole(role string) error {
+	if !core.IsValidTeamRole(role) {
+		return fmt.Errorf("invalid role '%s'. Use %s, %s, %s or %s", role,
+			core.TeamRoleOwner, core.TeamRoleEditor, core.TeamRoleCheckIn, core.TeamRoleViewer)
+	}
+	return nil
+}
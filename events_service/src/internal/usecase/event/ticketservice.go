@@ -0,0 +1,128 @@
+package event
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"eventservice/src/internal/core"
+	"eventservice/src/pkg/ticket"
+	"fmt"
+	"strings"
+)
+
+// GetTicket gets the signed ticket of a customer's booking for an event. The
+// ticket has no code while tickets are disabled.
+func (s *Service) GetTicket(userID int, eventID int) (*core.Ticket, error) {
+	t, err := s.repo.GetBookingTicket(userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if s.tickets != nil {
+		t.Code = s.tickets.Sign(t.BookingID, t.EventID)
+	}
+	return t, nil
+}
+
+// GetTicketQRCode renders the ticket code of a customer's booking as a PNG
+// of size pixels; 0 picks the default size
+func (s *Service) GetTicketQRCode(userID int, eventID int, size int) ([]byte, error) {
+	if size == 0 {
+		size = ticket.DefaultQRSize
+	}
+	if size < ticket.MinQRSize || size > ticket.MaxQRSize {
+		return nil, fmt.Errorf("size must be between %d and %d", ticket.MinQRSize, ticket.MaxQRSize)
+	}
+	if s.tickets == nil {
+		return nil, core.ErrTicketsDisabled
+	}
+
+	t, err := s.GetTicket(userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if t.Status != core.BookingStatusConfirmed {
+		return nil, fmt.Errorf("booking was cancelled")
+	}
+	return ticket.QRCode(t.Code, size)
+}
+
+// CheckIn validates a scanned ticket code and admits its booking to the
+// organizer's event. Codes are rejected before touching the database when the
+// signature is wrong or they were issued for another event.
+func (s *Service) CheckIn(eventID int, request *core.CheckInRequest, organizerID int) (*core.CheckIn, error) {
+	code := strings.TrimSpace(request.Code)
+	if code == "" {
+		return nil, fmt.Errorf("ticket code is required")
+	}
+	if s.tickets == nil {
+		return nil, core.ErrTicketsDisabled
+	}
+
+	bookingID, ticketEventID, err := s.tickets.Verify(code)
+	if err != nil {
+		return nil, err
+	}
+	if ticketEventID != eventID {
+		return nil, fmt.Errorf("ticket is for another event")
+	}
+
+	return s.repo.CheckInBooking(bookingID, eventID, organizerID)
+}
+
+// GetCheckInManifest exports the signed list of an event's bookings for door
+// apps to check tickets against while offline
+func (s *Service) GetCheckInManifest(eventID int, organizerID int) (*core.SignedManifest, error) {
+	if s.tickets == nil {
+		return nil, core.ErrTicketsDisabled
+	}
+	manifest, err := s.repo.GetCheckInManifest(eventID, organizerID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range manifest.Entries {
+		entry := &manifest.Entries[i]
+		entry.TicketHash = ticket.CodeHash(s.tickets.Sign(entry.BookingID, eventID))
+	}
+
+	payload, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %v", err)
+	}
+
+	return &core.SignedManifest{
+		Algorithm: ticket.ManifestAlgorithm,
+		KeyID:     s.tickets.ManifestKeyID(),
+		Payload:   base64.StdEncoding.EncodeToString(payload),
+		Signature: base64.StdEncoding.EncodeToString(s.tickets.SignManifest(payload)),
+	}, nil
+}
+
+// GetManifestKey returns the public key door apps verify manifests with
+func (s *Service) GetManifestKey() (*core.ManifestKey, error) {
+	if s.tickets == nil {
+		return nil, core.ErrTicketsDisabled
+	}
+	return &core.ManifestKey{
+		Algorithm: ticket.ManifestAlgorithm,
+		KeyID:     s.tickets.ManifestKeyID(),
+		PublicKey: base64.StdEncoding.EncodeToString(s.tickets.ManifestPublicKey()),
+	}, nil
+}
+
+// SyncCheckIns uploads the check-ins a door device recorded offline
+func (s *Service) SyncCheckIns(eventID int, request *core.CheckInSyncRequest, organizerID int) (*core.CheckInSyncResponse, error) {
+	deviceID := strings.TrimSpace(request.DeviceID)
+	if deviceID == "" {
+		return nil, fmt.Errorf("device ID is required")
+	}
+	if len(deviceID) > core.MaxDeviceIDLength {
+		return nil, fmt.Errorf("device ID must be at most %d characters", core.MaxDeviceIDLength)
+	}
+	if len(request.CheckIns) == 0 {
+		return nil, fmt.Errorf("no check-ins to sync")
+	}
+	if len(request.CheckIns) > core.MaxCheckInSyncBatch {
+		return nil, fmt.Errorf("at most %d check-ins can be synced at once", core.MaxCheckInSyncBatch)
+	}
+
+	return s.repo.SyncCheckIns(eventID, organizerID, deviceID, request.CheckIns)
+}
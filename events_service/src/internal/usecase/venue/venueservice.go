@@ -0,0 +1,66 @@
+package venue
+
+import (
+	"eventservice/src/internal/core"
+	"fmt"
+)
+
+type Service struct {
+	repo core.VenueRepository
+}
+
+func NewService(repo core.VenueRepository) Service {
+	return Service{repo: repo}
+}
+
+// CreateVenue creates a venue owned by the organizer
+func (s *Service) CreateVenue(req *core.CreateVenueRequest, organizerID int) (*core.Venue, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	venue := &core.Venue{
+		Name:        req.Name,
+		Address:     req.Address,
+		MaxCapacity: req.MaxCapacity,
+		OrganizerID: organizerID,
+		Shared:      req.Shared,
+		Latitude:    req.Latitude,
+		Longitude:   req.Longitude,
+	}
+
+	return s.repo.CreateVenue(venue)
+}
+
+// GetVenueByID gets a venue; private venues are only visible to their owner
+func (s *Service) GetVenueByID(venueID int, organizerID int) (*core.Venue, error) {
+	venue, err := s.repo.GetVenueByID(venueID)
+	if err != nil {
+		return nil, err
+	}
+	if !venue.Shared && venue.OrganizerID != organizerID {
+		return nil, fmt.Errorf("venue not found")
+	}
+	return venue, nil
+}
+
+// GetVenues lists the shared venues, plus the organizer's own when organizerID is set
+func (s *Service) GetVenues(filters *core.VenueFilters) ([]core.Venue, error) {
+	return s.repo.GetVenues(filters)
+}
+
+// UpdateVenue updates a venue owned by the organizer
+func (s *Service) UpdateVenue(venueID int, request *core.UpdateVenueRequest, organizerID int) (*core.Venue, error) {
+	if request.MaxCapacity < 0 {
+		return nil, fmt.Errorf("max capacity must be greater than 0")
+	}
+	if err := core.ValidateCoordinates(request.Latitude, request.Longitude); err != nil {
+		return nil, err
+	}
+	return s.repo.UpdateVenue(venueID, request, organizerID)
+}
+
+// DeleteVenue deletes a venue owned by the organizer that no event uses
+func (s *Service) DeleteVenue(venueID int, organizerID int) error {
+	return s.repo.DeleteVenue(venueID, organizerID)
+}
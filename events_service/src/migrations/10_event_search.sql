@@ -0,0 +1,12 @@
+-- Free-text description, searchable together with the name and place
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '';
+
+-- Matches in the name rank above the place, which ranks above the description
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english'::regconfig, event_name), 'A') ||
+        setweight(to_tsvector('english'::regconfig, place), 'B') ||
+        setweight(to_tsvector('english'::regconfig, description), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_events_search ON events_schema.events USING gin (search_vector);
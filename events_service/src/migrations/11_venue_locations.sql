@@ -0,0 +1,12 @@
+-- Optional position of a venue in degrees, for searching events near a point
+ALTER TABLE events_schema.venues ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE events_schema.venues ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+ALTER TABLE events_schema.venues DROP CONSTRAINT IF EXISTS check_venue_location;
+ALTER TABLE events_schema.venues ADD CONSTRAINT check_venue_location CHECK (
+    (latitude IS NULL AND longitude IS NULL) OR
+    (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180)
+);
+
+-- Near searches first narrow venues to a bounding box, see core.GeoPoint.BoundingBox
+CREATE INDEX IF NOT EXISTS idx_venues_location ON events_schema.venues (latitude, longitude) WHERE latitude IS NOT NULL;
@@ -0,0 +1,53 @@
+-- A booking holds one or more seats, optionally naming who sits in them
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS seats INTEGER NOT NULL DEFAULT 1 CHECK (seats > 0);
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS attendee_names TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE events_schema.event_waitlist ADD COLUMN IF NOT EXISTS seats INTEGER NOT NULL DEFAULT 1 CHECK (seats > 0);
+ALTER TABLE events_schema.event_waitlist ADD COLUMN IF NOT EXISTS attendee_names TEXT[] NOT NULL DEFAULT '{}';
+
+-- Set by the organizer; existing events keep one seat per booking
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS max_seats_per_booking INTEGER NOT NULL DEFAULT 1 CHECK (max_seats_per_booking > 0);
+
+-- Filled counts seats rather than bookings; partial cancellations update seats
+CREATE OR REPLACE FUNCTION events_schema.update_event_filled_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE events_schema.events 
+        SET filled = filled + NEW.seats 
+        WHERE event_id = NEW.event_id;
+        IF NEW.tier_id IS NOT NULL THEN
+            UPDATE events_schema.ticket_tiers
+            SET filled = filled + NEW.seats
+            WHERE tier_id = NEW.tier_id;
+        END IF;
+        RETURN NEW;
+    ELSIF TG_OP = 'UPDATE' THEN
+        UPDATE events_schema.events 
+        SET filled = filled + NEW.seats - OLD.seats 
+        WHERE event_id = NEW.event_id;
+        IF NEW.tier_id IS NOT NULL THEN
+            UPDATE events_schema.ticket_tiers
+            SET filled = filled + NEW.seats - OLD.seats
+            WHERE tier_id = NEW.tier_id;
+        END IF;
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE events_schema.events 
+        SET filled = filled - OLD.seats 
+        WHERE event_id = OLD.event_id;
+        IF OLD.tier_id IS NOT NULL THEN
+            UPDATE events_schema.ticket_tiers
+            SET filled = filled - OLD.seats
+            WHERE tier_id = OLD.tier_id;
+        END IF;
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_update_event_filled_seats ON events_schema.userbooked_events;
+
+CREATE TRIGGER trigger_update_event_filled_seats
+    AFTER UPDATE OF seats ON events_schema.userbooked_events
+    FOR EACH ROW EXECUTE FUNCTION events_schema.update_event_filled_count();
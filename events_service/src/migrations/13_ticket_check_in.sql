@@ -0,0 +1,2 @@
+-- Set when the ticket of a booking is scanned at the door; a ticket admits once
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMPTZ;
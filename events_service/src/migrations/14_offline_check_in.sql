@@ -0,0 +1,2 @@
+-- Door device an offline check-in was recorded on; NULL for online check-ins
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS checked_in_device TEXT;
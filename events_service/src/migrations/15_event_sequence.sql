@@ -0,0 +1,2 @@
+-- iCalendar SEQUENCE: revision of an event, bumped on every change attendees should see
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS sequence INTEGER NOT NULL DEFAULT 0;
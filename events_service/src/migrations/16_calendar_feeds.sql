@@ -0,0 +1,10 @@
+-- Private calendar subscriptions; only a SHA-256 of the secret token is kept
+CREATE TABLE IF NOT EXISTS events_schema.calendar_feeds (
+    feed_id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('bookings', 'organizer')),
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(), -- Reset when the token is rotated
+    last_accessed_at TIMESTAMPTZ,
+    UNIQUE (user_id, kind) -- One feed per user and kind
+);
@@ -0,0 +1,3 @@
+-- Custom answers given when booking, e.g. {"t_shirt_size": "M"}; kept while waitlisted
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS answers JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE events_schema.event_waitlist ADD COLUMN IF NOT EXISTS answers JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,16 @@
+-- Organizers working on an event and their role; see core.TeamRole*
+CREATE TABLE IF NOT EXISTS events_schema.event_team (
+    event_id INTEGER NOT NULL REFERENCES events_schema.events (event_id) ON DELETE CASCADE,
+    organizer_id INTEGER NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'editor', 'check_in', 'viewer')),
+    added_by INTEGER NOT NULL,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, organizer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_team_organizer ON events_schema.event_team (organizer_id);
+
+-- Every existing event is owned by the organizer who created it
+INSERT INTO events_schema.event_team (event_id, organizer_id, role, added_by)
+SELECT event_id, organizer_id, 'owner', organizer_id FROM events_schema.events
+ON CONFLICT (event_id, organizer_id) DO NOTHING;
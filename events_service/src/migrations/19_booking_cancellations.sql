@@ -0,0 +1,22 @@
+-- Seats given up by customers or cancelled with their event; bookings left by
+-- customers are deleted, so this log is what analytics count cancellations from
+CREATE TABLE IF NOT EXISTS events_schema.booking_cancellations (
+    cancellation_id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events_schema.events (event_id) ON DELETE CASCADE,
+    cid INTEGER NOT NULL,
+    seats INTEGER NOT NULL CHECK (seats > 0),
+    cancelled_by TEXT NOT NULL CHECK (cancelled_by IN ('customer', 'organizer')),
+    cancelled_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_cancellations_event ON events_schema.booking_cancellations (event_id, cancelled_at);
+
+-- Bookings of events cancelled before this log existed
+INSERT INTO events_schema.booking_cancellations (event_id, cid, seats, cancelled_by, cancelled_at)
+SELECT ub.event_id, ub.cid, ub.seats, 'organizer', e.updated_at
+FROM events_schema.userbooked_events ub
+JOIN events_schema.events e ON e.event_id = ub.event_id
+WHERE ub.status = 'cancelled_by_organizer'
+  AND NOT EXISTS (SELECT 1 FROM events_schema.booking_cancellations c WHERE c.event_id = ub.event_id AND c.cid = ub.cid);
+
+CREATE INDEX IF NOT EXISTS idx_userbooked_events_event_booked ON events_schema.userbooked_events (event_id, booked_at);
@@ -0,0 +1,58 @@
+-- Seats reserved for a customer during checkout. A hold keeps its seats out of
+-- sale until it is confirmed into a booking, released or expires.
+CREATE TABLE IF NOT EXISTS events_schema.seat_holds (
+    hold_id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events_schema.events (event_id) ON DELETE CASCADE,
+    cid INTEGER NOT NULL,
+    tier_id INTEGER REFERENCES events_schema.ticket_tiers (tier_id) ON DELETE CASCADE,
+    seats INTEGER NOT NULL CHECK (seats > 0),
+    attendee_names TEXT[] NOT NULL DEFAULT '{}',
+    answers JSONB NOT NULL DEFAULT '{}',
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (cid, event_id) -- One hold per customer and event
+);
+
+CREATE INDEX IF NOT EXISTS idx_seat_holds_expires_at ON events_schema.seat_holds (expires_at);
+
+-- Held seats are counted apart from booked ones; together they cannot exceed capacity
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS held INTEGER NOT NULL DEFAULT 0 CHECK (held >= 0);
+ALTER TABLE events_schema.events DROP CONSTRAINT IF EXISTS check_event_seats;
+ALTER TABLE events_schema.events ADD CONSTRAINT check_event_seats CHECK (filled + held <= capacity);
+
+ALTER TABLE events_schema.ticket_tiers ADD COLUMN IF NOT EXISTS held INTEGER NOT NULL DEFAULT 0 CHECK (held >= 0);
+ALTER TABLE events_schema.ticket_tiers DROP CONSTRAINT IF EXISTS check_tier_seats;
+ALTER TABLE events_schema.ticket_tiers ADD CONSTRAINT check_tier_seats CHECK (filled + held <= capacity);
+
+CREATE OR REPLACE FUNCTION events_schema.update_event_held_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE events_schema.events
+        SET held = held + NEW.seats
+        WHERE event_id = NEW.event_id;
+        IF NEW.tier_id IS NOT NULL THEN
+            UPDATE events_schema.ticket_tiers
+            SET held = held + NEW.seats
+            WHERE tier_id = NEW.tier_id;
+        END IF;
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE events_schema.events
+        SET held = held - OLD.seats
+        WHERE event_id = OLD.event_id;
+        IF OLD.tier_id IS NOT NULL THEN
+            UPDATE events_schema.ticket_tiers
+            SET held = held - OLD.seats
+            WHERE tier_id = OLD.tier_id;
+        END IF;
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_update_event_held_seats ON events_schema.seat_holds;
+
+CREATE TRIGGER trigger_update_event_held_seats
+    AFTER INSERT OR DELETE ON events_schema.seat_holds
+    FOR EACH ROW EXECUTE FUNCTION events_schema.update_event_held_count();
@@ -0,0 +1,35 @@
+-- Price per seat in the currency's minor unit (cents); 0 keeps an event free
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS price BIGINT NOT NULL DEFAULT 0 CHECK (price >= 0);
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT 'USD' CHECK (currency ~ '^[A-Z]{3}$');
+
+-- What was charged for a booking and how much of it has been refunded
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS payment_status TEXT NOT NULL DEFAULT 'none';
+ALTER TABLE events_schema.userbooked_events DROP CONSTRAINT IF EXISTS check_payment_status;
+ALTER TABLE events_schema.userbooked_events ADD CONSTRAINT check_payment_status
+    CHECK (payment_status IN ('none', 'paid', 'partially_refunded', 'refunded'));
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS payment_id TEXT;
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS unit_price BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS amount_paid BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS amount_refunded BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS currency TEXT NOT NULL DEFAULT 'USD';
+
+-- Refunds are queued in the transaction that releases the seats and sent to
+-- the payment provider afterwards, so a provider outage only delays them
+CREATE TABLE IF NOT EXISTS events_schema.payment_refunds (
+    refund_id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL,
+    cid INTEGER NOT NULL,
+    payment_id TEXT NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL,
+    reason TEXT NOT NULL CHECK (reason IN ('customer_cancelled', 'event_cancelled')),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    provider_refund_id TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    processed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_refunds_pending ON events_schema.payment_refunds (refund_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_payment_refunds_customer ON events_schema.payment_refunds (cid, created_at);
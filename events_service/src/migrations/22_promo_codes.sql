@@ -0,0 +1,41 @@
+-- Discount codes of an organizer, valid on one of their events or, without an
+-- event, on every event they created
+CREATE TABLE IF NOT EXISTS events_schema.promo_codes (
+    promo_id SERIAL PRIMARY KEY,
+    organizer_id INTEGER NOT NULL,
+    event_id INTEGER REFERENCES events_schema.events (event_id) ON DELETE CASCADE,
+    code TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('percentage', 'fixed')),
+    amount BIGINT NOT NULL CHECK (amount > 0), -- Percent off, or minor units off the booking
+    currency TEXT, -- Fixed discounts only apply to events priced in it
+    max_uses INTEGER CHECK (max_uses > 0),
+    max_uses_per_customer INTEGER CHECK (max_uses_per_customer > 0),
+    uses INTEGER NOT NULL DEFAULT 0,
+    valid_from TIMESTAMPTZ,
+    valid_until TIMESTAMPTZ,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_promo_percentage CHECK (kind <> 'percentage' OR amount <= 100),
+    CONSTRAINT check_promo_window CHECK (valid_from IS NULL OR valid_until IS NULL OR valid_until > valid_from),
+    CONSTRAINT check_promo_uses CHECK (max_uses IS NULL OR uses <= max_uses)
+);
+
+-- Codes are matched case-insensitively per organizer
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promo_codes_organizer_code ON events_schema.promo_codes (organizer_id, lower(code));
+
+-- Every booking a code was applied to
+CREATE TABLE IF NOT EXISTS events_schema.promo_redemptions (
+    redemption_id SERIAL PRIMARY KEY,
+    promo_id INTEGER NOT NULL REFERENCES events_schema.promo_codes (promo_id) ON DELETE CASCADE,
+    event_id INTEGER NOT NULL REFERENCES events_schema.events (event_id) ON DELETE CASCADE,
+    cid INTEGER NOT NULL,
+    seats INTEGER NOT NULL CHECK (seats > 0),
+    subtotal BIGINT NOT NULL,
+    discount BIGINT NOT NULL,
+    amount_paid BIGINT NOT NULL,
+    currency TEXT NOT NULL,
+    payment_id TEXT,
+    redeemed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_promo ON events_schema.promo_redemptions (promo_id, cid);
@@ -0,0 +1,13 @@
+-- Per-event cancellation policy: customers may leave until cutoff minutes
+-- before the start, or until the start with a cutoff of 0; later cancellations
+-- are refused or flagged as late
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS cancellation_cutoff_minutes INTEGER NOT NULL DEFAULT 0 CHECK (cancellation_cutoff_minutes >= 0);
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS cancellation_mode TEXT NOT NULL DEFAULT 'refuse' CHECK (cancellation_mode IN ('refuse', 'flag'));
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS late_cancellation_penalty BIGINT NOT NULL DEFAULT 0 CHECK (late_cancellation_penalty >= 0);
+
+-- Late cancellations and the penalty recorded against the customer
+ALTER TABLE events_schema.booking_cancellations ADD COLUMN IF NOT EXISTS late BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE events_schema.booking_cancellations ADD COLUMN IF NOT EXISTS penalty BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE events_schema.booking_cancellations ADD COLUMN IF NOT EXISTS currency TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_booking_cancellations_late ON events_schema.booking_cancellations (cid, cancelled_at) WHERE late;
@@ -0,0 +1,12 @@
+-- Waitlist for customers who tried to join a full event (FIFO by joined_at)
+CREATE TABLE IF NOT EXISTS events_schema.event_waitlist (
+    waitlist_id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events_schema.events (event_id) ON DELETE CASCADE,
+    cid INTEGER NOT NULL,
+    cemail TEXT NOT NULL,
+    cusername TEXT NOT NULL,
+    joined_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (cid, event_id) -- A customer can only queue once per event
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_waitlist_event_order ON events_schema.event_waitlist (event_id, joined_at, waitlist_id);
@@ -0,0 +1,71 @@
+-- Event lifecycle: draft -> published -> cancelled / completed
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'published';
+
+ALTER TABLE events_schema.events ADD CONSTRAINT check_event_status
+    CHECK (status IN ('draft', 'published', 'cancelled', 'completed'));
+
+CREATE INDEX IF NOT EXISTS idx_events_status ON events_schema.events (status);
+
+-- Bookings of cancelled events are kept for history instead of being deleted
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'confirmed';
+
+ALTER TABLE events_schema.userbooked_events ADD CONSTRAINT check_booking_status
+    CHECK (status IN ('confirmed', 'cancelled_by_organizer'));
+
+-- Cancelled events no longer hold their place
+CREATE OR REPLACE FUNCTION events_schema.check_place_availability(
+    p_place TEXT,
+    p_event_date DATE,
+    p_start_time TIME,
+    p_end_time TIME,
+    p_exclude_event_id INTEGER DEFAULT NULL
+) RETURNS BOOLEAN AS $$
+DECLARE
+    conflict_count INTEGER;
+BEGIN
+    SELECT COUNT(*)
+    INTO conflict_count
+    FROM events_schema.events
+    WHERE place = p_place
+      AND event_date = p_event_date
+      AND status <> 'cancelled'
+      AND (
+          (start_time <= p_start_time AND end_time > p_start_time) OR
+          (start_time < p_end_time AND end_time >= p_end_time) OR
+          (start_time >= p_start_time AND end_time <= p_end_time)
+      )
+      AND (p_exclude_event_id IS NULL OR event_id != p_exclude_event_id);
+    
+    RETURN conflict_count = 0; -- Return true if no conflicts (place is available)
+END;
+$$ LANGUAGE plpgsql;
+
+-- Only confirmed bookings of live events block a customer's time
+CREATE OR REPLACE FUNCTION events_schema.check_customer_time_conflict(
+    p_customer_id INTEGER,
+    p_event_date DATE,
+    p_start_time TIME,
+    p_end_time TIME,
+    p_exclude_event_id INTEGER DEFAULT NULL
+) RETURNS BOOLEAN AS $$
+DECLARE
+    conflict_count INTEGER;
+BEGIN
+    SELECT COUNT(*)
+    INTO conflict_count
+    FROM events_schema.userbooked_events ub
+    JOIN events_schema.events e ON ub.event_id = e.event_id
+    WHERE ub.cid = p_customer_id
+      AND ub.status = 'confirmed'
+      AND e.status <> 'cancelled'
+      AND e.event_date = p_event_date
+      AND (
+          (e.start_time <= p_start_time AND e.end_time > p_start_time) OR
+          (e.start_time < p_end_time AND e.end_time >= p_end_time) OR
+          (e.start_time >= p_start_time AND e.end_time <= p_end_time)
+      )
+      AND (p_exclude_event_id IS NULL OR e.event_id != p_exclude_event_id);
+    
+    RETURN conflict_count > 0; -- Return true if there's a conflict
+END;
+$$ LANGUAGE plpgsql;
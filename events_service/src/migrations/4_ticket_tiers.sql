@@ -0,0 +1,54 @@
+-- Ticket tiers split an event's capacity into types (General, VIP, ...)
+CREATE TABLE IF NOT EXISTS events_schema.ticket_tiers (
+    tier_id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events_schema.events (event_id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    filled INTEGER NOT NULL DEFAULT 0 CHECK (filled >= 0 AND filled <= capacity),
+    sales_start TIMESTAMPTZ,
+    sales_end TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (event_id, name),
+    CONSTRAINT check_sales_window CHECK (sales_start IS NULL OR sales_end IS NULL OR sales_end > sales_start)
+);
+
+-- Bookings and waitlist entries of tiered events reference the chosen tier
+ALTER TABLE events_schema.userbooked_events ADD COLUMN IF NOT EXISTS tier_id INTEGER REFERENCES events_schema.ticket_tiers (tier_id);
+
+ALTER TABLE events_schema.event_waitlist ADD COLUMN IF NOT EXISTS tier_id INTEGER REFERENCES events_schema.ticket_tiers (tier_id) ON DELETE CASCADE;
+
+-- Keep the tier's filled count in step with the event's
+CREATE OR REPLACE FUNCTION events_schema.update_event_filled_count() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE events_schema.events 
+        SET filled = filled + 1 
+        WHERE event_id = NEW.event_id;
+        IF NEW.tier_id IS NOT NULL THEN
+            UPDATE events_schema.ticket_tiers
+            SET filled = filled + 1
+            WHERE tier_id = NEW.tier_id;
+        END IF;
+        RETURN NEW;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE events_schema.events 
+        SET filled = filled - 1 
+        WHERE event_id = OLD.event_id;
+        IF OLD.tier_id IS NOT NULL THEN
+            UPDATE events_schema.ticket_tiers
+            SET filled = filled - 1
+            WHERE tier_id = OLD.tier_id;
+        END IF;
+        RETURN OLD;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_update_ticket_tiers_updated_at ON events_schema.ticket_tiers;
+
+CREATE TRIGGER trigger_update_ticket_tiers_updated_at
+    BEFORE UPDATE ON events_schema.ticket_tiers
+    FOR EACH ROW EXECUTE FUNCTION events_schema.update_updated_at_column();
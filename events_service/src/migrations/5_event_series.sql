@@ -0,0 +1,12 @@
+-- Recurring series; each occurrence is a regular event linked to its series
+CREATE TABLE IF NOT EXISTS events_schema.event_series (
+    series_id SERIAL PRIMARY KEY,
+    organizer_id INTEGER NOT NULL,
+    rrule TEXT NOT NULL,
+    exdates DATE[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS series_id INTEGER REFERENCES events_schema.event_series (series_id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_series ON events_schema.events (series_id, event_date);
@@ -0,0 +1,72 @@
+-- Events run between two instants so they can cross midnight or span several days
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS starts_at TIMESTAMPTZ;
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS ends_at TIMESTAMPTZ;
+
+-- Existing rows were stored without a zone and are read as UTC
+UPDATE events_schema.events
+SET starts_at = (event_date + start_time) AT TIME ZONE 'UTC',
+    ends_at = (event_date + end_time) AT TIME ZONE 'UTC'
+WHERE starts_at IS NULL;
+
+ALTER TABLE events_schema.events ALTER COLUMN starts_at SET NOT NULL;
+ALTER TABLE events_schema.events ALTER COLUMN ends_at SET NOT NULL;
+
+ALTER TABLE events_schema.events DROP CONSTRAINT IF EXISTS check_end_time_after_start;
+ALTER TABLE events_schema.events ADD CONSTRAINT check_ends_after_starts CHECK (ends_at > starts_at);
+
+DROP INDEX IF EXISTS events_schema.idx_events_series;
+
+DROP FUNCTION IF EXISTS events_schema.check_place_availability(TEXT, DATE, TIME, TIME, INTEGER);
+DROP FUNCTION IF EXISTS events_schema.check_customer_time_conflict(INTEGER, DATE, TIME, TIME, INTEGER);
+
+ALTER TABLE events_schema.events DROP COLUMN IF EXISTS event_date;
+ALTER TABLE events_schema.events DROP COLUMN IF EXISTS start_time;
+ALTER TABLE events_schema.events DROP COLUMN IF EXISTS end_time;
+
+CREATE INDEX IF NOT EXISTS idx_events_starts_at ON events_schema.events (starts_at);
+CREATE INDEX IF NOT EXISTS idx_events_series ON events_schema.events (series_id, starts_at);
+
+-- Two ranges conflict when they overlap; touching ends (10:00-12:00 and 12:00-14:00) do not
+CREATE OR REPLACE FUNCTION events_schema.check_place_availability(
+    p_place TEXT,
+    p_starts_at TIMESTAMPTZ,
+    p_ends_at TIMESTAMPTZ,
+    p_exclude_event_id INTEGER DEFAULT NULL
+) RETURNS BOOLEAN AS $$
+DECLARE
+    conflict_count INTEGER;
+BEGIN
+    SELECT COUNT(*)
+    INTO conflict_count
+    FROM events_schema.events
+    WHERE place = p_place
+      AND status <> 'cancelled'
+      AND tstzrange(starts_at, ends_at) && tstzrange(p_starts_at, p_ends_at)
+      AND (p_exclude_event_id IS NULL OR event_id != p_exclude_event_id);
+
+    RETURN conflict_count = 0; -- Return true if no conflicts (place is available)
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION events_schema.check_customer_time_conflict(
+    p_customer_id INTEGER,
+    p_starts_at TIMESTAMPTZ,
+    p_ends_at TIMESTAMPTZ,
+    p_exclude_event_id INTEGER DEFAULT NULL
+) RETURNS BOOLEAN AS $$
+DECLARE
+    conflict_count INTEGER;
+BEGIN
+    SELECT COUNT(*)
+    INTO conflict_count
+    FROM events_schema.userbooked_events ub
+    JOIN events_schema.events e ON ub.event_id = e.event_id
+    WHERE ub.cid = p_customer_id
+      AND ub.status = 'confirmed'
+      AND e.status <> 'cancelled'
+      AND tstzrange(e.starts_at, e.ends_at) && tstzrange(p_starts_at, p_ends_at)
+      AND (p_exclude_event_id IS NULL OR e.event_id != p_exclude_event_id);
+
+    RETURN conflict_count > 0; -- Return true if there's a conflict
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,2 @@
+-- IANA zone each event is scheduled in; starts_at/ends_at stay absolute instants
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,74 @@
+-- Venues replace the free-text place; events.place is kept as the venue name
+CREATE TABLE IF NOT EXISTS events_schema.venues (
+    venue_id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    name_key TEXT NOT NULL, -- Lowercased with whitespace collapsed, see core.NormalizeVenueName
+    address TEXT NOT NULL DEFAULT '',
+    max_capacity INTEGER NOT NULL CHECK (max_capacity > 0),
+    organizer_id INTEGER NOT NULL,
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- An organizer cannot have two venues of the same name, nor can two shared venues
+CREATE UNIQUE INDEX IF NOT EXISTS idx_venues_owner_name ON events_schema.venues (organizer_id, name_key);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_venues_shared_name ON events_schema.venues (name_key) WHERE shared;
+
+DROP TRIGGER IF EXISTS trigger_update_venues_updated_at ON events_schema.venues;
+
+CREATE TRIGGER trigger_update_venues_updated_at
+    BEFORE UPDATE ON events_schema.venues
+    FOR EACH ROW EXECUTE FUNCTION events_schema.update_updated_at_column();
+
+-- One venue per distinct normalized place, owned by whoever used it first and
+-- shared when several organizers already hold events there
+INSERT INTO events_schema.venues (name, name_key, max_capacity, organizer_id, shared)
+SELECT
+    (ARRAY_AGG(btrim(place) ORDER BY created_at, event_id))[1],
+    name_key,
+    MAX(capacity),
+    (ARRAY_AGG(organizer_id ORDER BY created_at, event_id))[1],
+    COUNT(DISTINCT organizer_id) > 1
+FROM (
+    SELECT event_id, place, capacity, organizer_id, created_at,
+           lower(btrim(regexp_replace(place, '\s+', ' ', 'g'))) AS name_key
+    FROM events_schema.events
+) places
+GROUP BY name_key;
+
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS venue_id INTEGER REFERENCES events_schema.venues (venue_id);
+
+UPDATE events_schema.events e
+SET venue_id = v.venue_id, place = v.name
+FROM events_schema.venues v
+WHERE e.venue_id IS NULL
+  AND v.name_key = lower(btrim(regexp_replace(e.place, '\s+', ' ', 'g')));
+
+ALTER TABLE events_schema.events ALTER COLUMN venue_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_venue ON events_schema.events (venue_id, starts_at);
+
+-- Availability is decided by venue rather than by the spelling of the place
+DROP FUNCTION IF EXISTS events_schema.check_place_availability(TEXT, TIMESTAMPTZ, TIMESTAMPTZ, INTEGER);
+
+CREATE OR REPLACE FUNCTION events_schema.check_venue_availability(
+    p_venue_id INTEGER,
+    p_starts_at TIMESTAMPTZ,
+    p_ends_at TIMESTAMPTZ,
+    p_exclude_event_id INTEGER DEFAULT NULL
+) RETURNS BOOLEAN AS $$
+DECLARE
+    conflict_count INTEGER;
+BEGIN
+    SELECT COUNT(*)
+    INTO conflict_count
+    FROM events_schema.events
+    WHERE venue_id = p_venue_id
+      AND status <> 'cancelled'
+      AND tstzrange(starts_at, ends_at) && tstzrange(p_starts_at, p_ends_at)
+      AND (p_exclude_event_id IS NULL OR event_id != p_exclude_event_id);
+
+    RETURN conflict_count = 0; -- Return true if no conflicts (venue is available)
+END;
+$$ LANGUAGE plpgsql;
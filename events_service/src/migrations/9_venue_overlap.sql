@@ -0,0 +1,62 @@
+-- The database itself keeps live events at one venue from overlapping, so
+-- concurrent creates and updates cannot both pass an availability check
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE events_schema.events ADD COLUMN IF NOT EXISTS time_range TSTZRANGE
+    GENERATED ALWAYS AS (tstzrange(starts_at, ends_at)) STORED;
+
+-- Merging place spellings into venues can leave live events overlapping at one
+-- venue, which the constraint would reject. Every event overlapping an earlier
+-- one at its venue moves to a venue of its own, recorded here for review.
+CREATE TABLE IF NOT EXISTS events_schema.venue_overlap_splits (
+    event_id INTEGER PRIMARY KEY REFERENCES events_schema.events (event_id) ON DELETE CASCADE,
+    original_venue_id INTEGER NOT NULL REFERENCES events_schema.venues (venue_id),
+    venue_id INTEGER REFERENCES events_schema.venues (venue_id),
+    split_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO events_schema.venue_overlap_splits (event_id, original_venue_id)
+SELECT e.event_id, e.venue_id
+FROM events_schema.events e
+WHERE e.status <> 'cancelled'
+  AND EXISTS (
+      SELECT 1 FROM events_schema.events o
+      WHERE o.venue_id = e.venue_id
+        AND o.status <> 'cancelled'
+        AND o.event_id <> e.event_id
+        AND o.time_range && e.time_range
+        AND (o.starts_at, o.event_id) < (e.starts_at, e.event_id))
+ON CONFLICT (event_id) DO NOTHING;
+
+INSERT INTO events_schema.venues (name, name_key, address, max_capacity, organizer_id, shared)
+SELECT v.name || ' (event ' || s.event_id || ')', v.name_key || ' (event ' || s.event_id || ')', v.address,
+       GREATEST(v.max_capacity, e.capacity), e.organizer_id, FALSE
+FROM events_schema.venue_overlap_splits s
+JOIN events_schema.events e ON e.event_id = s.event_id
+JOIN events_schema.venues v ON v.venue_id = s.original_venue_id
+WHERE s.venue_id IS NULL
+ON CONFLICT DO NOTHING;
+
+UPDATE events_schema.venue_overlap_splits s
+SET venue_id = v.venue_id
+FROM events_schema.events e, events_schema.venues o, events_schema.venues v
+WHERE e.event_id = s.event_id
+  AND o.venue_id = s.original_venue_id
+  AND v.organizer_id = e.organizer_id
+  AND v.name_key = o.name_key || ' (event ' || s.event_id || ')'
+  AND s.venue_id IS NULL;
+
+UPDATE events_schema.events e
+SET venue_id = v.venue_id, place = v.name
+FROM events_schema.venue_overlap_splits s
+JOIN events_schema.venues v ON v.venue_id = s.venue_id
+WHERE e.event_id = s.event_id
+  AND e.venue_id = s.original_venue_id;
+
+ALTER TABLE events_schema.events DROP CONSTRAINT IF EXISTS events_venue_no_overlap;
+ALTER TABLE events_schema.events ADD CONSTRAINT events_venue_no_overlap
+    EXCLUDE USING gist (venue_id WITH =, time_range WITH &&)
+    WHERE (status <> 'cancelled');
+
+-- Replaced by the constraint
+DROP FUNCTION IF EXISTS events_schema.check_venue_availability(INTEGER, TIMESTAMPTZ, TIMESTAMPTZ, INTEGER);
@@ -0,0 +1,128 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of iCalendar data (RFC 5545 section 8.1)
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineOctets is the longest content line allowed before folding, excluding CRLF
+const maxLineOctets = 75
+
+// utcLayout formats DATE-TIME values in UTC ("FORM #2")
+const utcLayout = "20060102T150405Z"
+
+// Event statuses (RFC 5545 STATUS for VEVENT)
+const (
+	StatusTentative = "TENTATIVE"
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+// Event is a VEVENT. UID must stay the same for the life of the event and
+// Sequence must grow whenever it is changed, so calendar apps update their copy.
+type Event struct {
+	UID          string
+	Sequence     int
+	Summary      string
+	Description  string
+	Location     string
+	Start        time.Time
+	End          time.Time
+	Status       string // One of the Status constants, omitted when empty
+	Created      time.Time
+	LastModified time.Time // Also used as DTSTAMP
+}
+
+// Calendar is a VCALENDAR holding events
+type Calendar struct {
+	ProdID string // Identifies the product that created the calendar
+	Name   string // Display name (X-WR-CALNAME), omitted when empty
+	Events []Event
+}
+
+// Encode renders the calendar as RFC 5545 text with CRLF line endings and
+// lines folded at 75 octets
+func (c *Calendar) Encode() []byte {
+	var buf bytes.Buffer
+	line := func(name, value string) {
+		writeFolded(&buf, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", c.ProdID)
+	line("CALSCALE", "GREGORIAN")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escapeText(c.Name))
+	}
+
+	for _, event := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", event.UID)
+		// Without a METHOD, DTSTAMP is the last modification time
+		line("DTSTAMP", formatTime(event.LastModified))
+		line("DTSTART", formatTime(event.Start))
+		line("DTEND", formatTime(event.End))
+		line("SEQUENCE", fmt.Sprint(event.Sequence))
+		line("SUMMARY", escapeText(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escapeText(event.Description))
+		}
+		if event.Location != "" {
+			line("LOCATION", escapeText(event.Location))
+		}
+		if event.Status != "" {
+			line("STATUS", event.Status)
+		}
+		if !event.Created.IsZero() {
+			line("CREATED", formatTime(event.Created))
+		}
+		line("LAST-MODIFIED", formatTime(event.LastModified))
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return buf.Bytes()
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(utcLayout)
+}
+
+// escapeText escapes a TEXT value (RFC 5545 section 3.3.11)
+func escapeText(value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// writeFolded writes a content line, continuing it on lines starting with a
+// space whenever it exceeds 75 octets. Multi-byte characters are never split.
+func writeFolded(buf *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space counts towards the continuation line's length
+		limit = maxLineOctets - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
@@ -0,0 +1,200 @@
+package recurrence
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxOccurrences caps how many dates a single rule may expand to
+const MaxOccurrences = 366
+
+// Supported frequencies (RFC 5545 FREQ subset)
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// Rule is a parsed recurrence rule. Exactly one of Count and Until is set.
+type Rule struct {
+	Freq     string
+	Interval int
+	Count    int
+	Until    time.Time      // Date only, inclusive
+	ByDay    []time.Weekday // Only for WEEKLY
+}
+
+// Parse parses an RRULE value such as "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10".
+// An optional "RRULE:" prefix is accepted.
+func Parse(value string) (*Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("recurrence rule is required")
+	}
+
+	rule := &Rule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, fmt.Errorf("invalid recurrence rule part '%s'", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			freq := strings.ToUpper(val)
+			if freq != Daily && freq != Weekly && freq != Monthly {
+				return nil, fmt.Errorf("unsupported FREQ '%s', use DAILY, WEEKLY or MONTHLY", val)
+			}
+			rule.Freq = freq
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("INTERVAL must be a positive integer")
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("COUNT must be a positive integer")
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY value '%s'", day)
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported recurrence rule part '%s'", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("FREQ is required")
+	}
+	if rule.Count == 0 && rule.Until.IsZero() {
+		return nil, fmt.Errorf("either COUNT or UNTIL is required")
+	}
+	if rule.Count != 0 && !rule.Until.IsZero() {
+		return nil, fmt.Errorf("COUNT and UNTIL cannot be used together")
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != Weekly {
+		return nil, fmt.Errorf("BYDAY is only supported with FREQ=WEEKLY")
+	}
+
+	return rule, nil
+}
+
+// parseUntil accepts the RFC 5545 DATE and UTC DATE-TIME forms
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102", "20060102T150405Z"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			return time.Date(until.Year(), until.Month(), until.Day(), 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL value '%s', use YYYYMMDD", value)
+}
+
+// Occurrences expands the rule into dates starting at start (which is the
+// first occurrence when it matches the rule). Dates in exdates are dropped
+// after expansion, so as in RFC 5545 they still count towards COUNT.
+func (r *Rule) Occurrences(start time.Time, exdates []time.Time) ([]time.Time, error) {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+
+	excluded := make(map[string]bool)
+	for _, exdate := range exdates {
+		excluded[exdate.Format("2006-01-02")] = true
+	}
+
+	var dates []time.Time
+	generated := 0
+	emit := func(date time.Time) (bool, error) {
+		if !r.Until.IsZero() && date.After(r.Until) {
+			return false, nil
+		}
+		generated++
+		if generated > MaxOccurrences {
+			return false, fmt.Errorf("recurrence expands to more than %d occurrences", MaxOccurrences)
+		}
+		if !excluded[date.Format("2006-01-02")] {
+			dates = append(dates, date)
+		}
+		return r.Count == 0 || generated < r.Count, nil
+	}
+
+	switch r.Freq {
+	case Daily:
+		for date := start; ; date = date.AddDate(0, 0, r.Interval) {
+			more, err := emit(date)
+			if err != nil || !more {
+				return dates, err
+			}
+		}
+	case Weekly:
+		byDay := r.ByDay
+		if len(byDay) == 0 {
+			byDay = []time.Weekday{start.Weekday()}
+		}
+		// Weeks start on Monday (RFC 5545 default WKST)
+		weekStart := start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+		for ; ; weekStart = weekStart.AddDate(0, 0, 7*r.Interval) {
+			for offset := 0; offset < 7; offset++ {
+				date := weekStart.AddDate(0, 0, offset)
+				if date.Before(start) || !containsWeekday(byDay, date.Weekday()) {
+					continue
+				}
+				more, err := emit(date)
+				if err != nil || !more {
+					return dates, err
+				}
+			}
+		}
+	case Monthly:
+		for month := 0; month <= MaxOccurrences*12; month += r.Interval {
+			first := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, month, 0)
+			// Months without the start day (e.g. the 31st) are skipped
+			date := first.AddDate(0, 0, start.Day()-1)
+			if date.Month() != first.Month() {
+				if !r.Until.IsZero() && first.After(r.Until) {
+					return dates, nil
+				}
+				continue
+			}
+			more, err := emit(date)
+			if err != nil || !more {
+				return dates, err
+			}
+		}
+	}
+
+	return dates, nil
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
@@ -11,6 +11,8 @@ type StandardResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 	Message string      `json:"message,omitempty"`
 	Error   error       `json:"error,omitempty"`
+	// NextCursor fetches the following page of a paginated listing
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 func WriteResponse(w http.ResponseWriter, statuscode int, resp StandardResponse) {
@@ -36,6 +38,17 @@ func WriteSuccess(w http.ResponseWriter, statusCode int, message string, data in
 	WriteResponse(w, statusCode, resp)
 }
 
+// WritePage writes a successful response holding one page of a listing
+func WritePage(w http.ResponseWriter, statusCode int, message string, data interface{}, nextCursor string) {
+	resp := StandardResponse{
+		Status:     "success",
+		Message:    message,
+		Data:       data,
+		NextCursor: nextCursor,
+	}
+	WriteResponse(w, statusCode, resp)
+}
+
 // WriteError writes an error response
 func WriteError(w http.ResponseWriter, statusCode int, message string) {
 	resp := StandardResponse{
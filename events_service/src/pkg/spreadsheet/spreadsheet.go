@@ -0,0 +1,144 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Supported formats
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// utf8BOM lets spreadsheet apps detect that a CSV file is UTF-8
+const utf8BOM = "\uFEFF"
+
+// Writer writes a table row by row. Values may be strings, numbers or bools.
+// Close must be called to finish the file.
+type Writer interface {
+	WriteHeader(names []string) error
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+// NewWriter returns a Writer producing format on w; sheet names the worksheet of XLSX files
+func NewWriter(format string, w io.Writer, sheet string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		if _, err := io.WriteString(w, utf8BOM); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w, sheet)
+	default:
+		return nil, fmt.Errorf("invalid format '%s'. Use csv or xlsx", format)
+	}
+}
+
+// ContentType returns the media type of a format
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) WriteHeader(names []string) error {
+	// Answer columns are named after customer supplied question keys
+	record := make([]string, len(names))
+	for i, name := range names {
+		record[i] = escapeFormula(name)
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		if s, ok := value.(string); ok {
+			record[i] = escapeFormula(s)
+		} else {
+			record[i] = fmt.Sprint(value)
+		}
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// escapeFormula keeps spreadsheet apps from evaluating user input as a formula
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+type xlsxWriter struct {
+	out         io.Writer
+	file        *excelize.File
+	stream      *excelize.StreamWriter
+	headerStyle int
+	row         int
+}
+
+func newXLSXWriter(w io.Writer, sheet string) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName("Sheet1", sheet); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to create sheet: %v", err)
+	}
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to create sheet: %v", err)
+	}
+	headerStyle, err := file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to create header style: %v", err)
+	}
+	return &xlsxWriter{out: w, file: file, stream: stream, headerStyle: headerStyle}, nil
+}
+
+func (x *xlsxWriter) WriteHeader(names []string) error {
+	values := make([]interface{}, len(names))
+	for i, name := range names {
+		values[i] = name
+	}
+	return x.setRow(values, excelize.RowOpts{StyleID: x.headerStyle})
+}
+
+func (x *xlsxWriter) WriteRow(values []interface{}) error {
+	return x.setRow(values)
+}
+
+func (x *xlsxWriter) setRow(values []interface{}, opts ...excelize.RowOpts) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, values, opts...)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return fmt.Errorf("failed to write sheet: %v", err)
+	}
+	return x.file.Write(x.out)
+}
@@ -0,0 +1,126 @@
+package ticket
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// version prefixes every code so the format can change without breaking old tickets
+const version = "v1"
+
+// MinSecretLength is the shortest secret accepted for signing
+const MinSecretLength = 16
+
+// QR code sizes in pixels
+const (
+	DefaultQRSize = 256
+	MinQRSize     = 64
+	MaxQRSize     = 1024
+)
+
+// ErrInvalidCode is returned for codes that were not signed with the secret
+var ErrInvalidCode = errors.New("invalid ticket code")
+
+// ManifestAlgorithm names the signature scheme of check-in manifests
+const ManifestAlgorithm = "Ed25519"
+
+// Signer signs and verifies ticket codes of the form "v1.<booking>.<event>.<mac>",
+// where mac is the HMAC-SHA256 of the rest of the code. It also signs check-in
+// manifests with an Ed25519 key derived from the same secret, so door apps can
+// verify manifests with the public key without being able to forge tickets.
+type Signer struct {
+	secret      []byte
+	manifestKey ed25519.PrivateKey
+}
+
+// NewSigner returns a Signer using secret, which must be kept private to the service
+func NewSigner(secret string) (*Signer, error) {
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("ticket secret must be at least %d characters", MinSecretLength)
+	}
+	s := &Signer{secret: []byte(secret)}
+	s.manifestKey = ed25519.NewKeyFromSeed(s.mac("manifest-signing-key"))
+	return s, nil
+}
+
+// Sign returns the code of a booking's ticket
+func (s *Signer) Sign(bookingID, eventID int) string {
+	payload := fmt.Sprintf("%s.%d.%d", version, bookingID, eventID)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(s.mac(payload))
+}
+
+// Verify checks a code's signature and returns the booking and event it was issued for
+func (s *Signer) Verify(code string) (bookingID, eventID int, err error) {
+	code = strings.TrimSpace(code)
+	split := strings.LastIndexByte(code, '.')
+	if split < 0 {
+		return 0, 0, ErrInvalidCode
+	}
+	payload := code[:split]
+
+	mac, err := base64.RawURLEncoding.DecodeString(code[split+1:])
+	if err != nil || !hmac.Equal(mac, s.mac(payload)) {
+		return 0, 0, ErrInvalidCode
+	}
+
+	parts := strings.Split(payload, ".")
+	if len(parts) != 3 || parts[0] != version {
+		return 0, 0, ErrInvalidCode
+	}
+	bookingID, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, ErrInvalidCode
+	}
+	eventID, err = strconv.Atoi(parts[2])
+	if err != nil {
+		return 0, 0, ErrInvalidCode
+	}
+	return bookingID, eventID, nil
+}
+
+// CodeHash returns the hex SHA-256 of a code. Manifests carry it instead of the
+// code, so door apps can recognise scanned tickets without holding valid codes.
+func CodeHash(code string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// SignManifest returns the Ed25519 signature of a manifest payload
+func (s *Signer) SignManifest(payload []byte) []byte {
+	return ed25519.Sign(s.manifestKey, payload)
+}
+
+// ManifestPublicKey returns the key manifests are verified with
+func (s *Signer) ManifestPublicKey() ed25519.PublicKey {
+	return s.manifestKey.Public().(ed25519.PublicKey)
+}
+
+// ManifestKeyID identifies the manifest key, changing whenever the secret does
+func (s *Signer) ManifestKeyID() string {
+	sum := sha256.Sum256(s.ManifestPublicKey())
+	return hex.EncodeToString(sum[:8])
+}
+
+func (s *Signer) mac(payload string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
+
+// QRCode renders a code as a square PNG of size pixels
+func QRCode(code string, size int) ([]byte, error) {
+	png, err := qrcode.Encode(code, qrcode.Medium, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render QR code: %v", err)
+	}
+	return png, nil
+}